@@ -1,18 +1,49 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/helgesverre/sql-splitter/internal/analyzer"
+	"github.com/helgesverre/sql-splitter/internal/bytefmt"
+	"github.com/helgesverre/sql-splitter/internal/compress"
+	"github.com/helgesverre/sql-splitter/internal/parser"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
 var (
-	showProgress bool
+	showProgress        bool
+	compressionFlag     string
+	analyzeFormat       string
+	checkpointPath      string
+	checkpointInterval  int
+	resumeFromPath      string
+	verifyCheckpointRun bool
+	analyzeFsFlag       string
+	analyzeDialectFlag  string
 )
 
+// analyzeOutput is the top-level shape emitted by --format=json
+type analyzeOutput struct {
+	File      string                 `json:"file"`
+	SizeBytes int64                  `json:"size_bytes"`
+	ElapsedMs int64                  `json:"elapsed_ms"`
+	Tables    []*analyzer.TableStats `json:"tables"`
+	Totals    analyzeTotals          `json:"totals"`
+}
+
+// analyzeTotals summarizes counts across all tables
+type analyzeTotals struct {
+	Inserts    int64 `json:"inserts"`
+	Statements int64 `json:"statements"`
+	Bytes      int64 `json:"bytes"`
+}
+
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze [file]",
 	Short: "Analyze a SQL file and display statistics",
@@ -26,9 +57,23 @@ The analyze command scans the SQL file and reports on:
 
 Results are sorted by INSERT count in descending order.
 
+Use --format to emit machine-readable output (json, ndjson, csv) instead of
+the default fixed-width table, for scripted usage such as dashboards or CI
+table-size gating.
+
+For multi-hundred-GB dumps, --checkpoint periodically saves the byte offset
+and accumulated stats so an interrupted analysis can continue later with
+--resume instead of starting over.
+
 Example:
   sql-splitter analyze large-dump.sql
-  sql-splitter analyze database.sql --progress`,
+  sql-splitter analyze database.sql --progress
+  sql-splitter analyze database.sql --format=json
+  sql-splitter analyze database.sql --format=ndjson | jq .
+  sql-splitter analyze huge-dump.sql --checkpoint=checkpoint.json
+  sql-splitter analyze huge-dump.sql --resume=checkpoint.json
+  sql-splitter analyze dump.sql --fs=mem
+  sql-splitter analyze pg_dump.sql --dialect=postgres`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAnalyze,
 }
@@ -37,44 +82,115 @@ func init() {
 	rootCmd.AddCommand(analyzeCmd)
 
 	analyzeCmd.Flags().BoolVarP(&showProgress, "progress", "p", false, "Show progress bar during analysis")
+	analyzeCmd.Flags().StringVar(&compressionFlag, "compression", "auto", "Input compression: auto, none, gzip, zstd, snappy")
+	analyzeCmd.Flags().StringVar(&analyzeFormat, "format", "table", "Output format: table, json, ndjson, csv")
+	analyzeCmd.Flags().StringVar(&checkpointPath, "checkpoint", "", "Periodically write a resumable checkpoint to this path")
+	analyzeCmd.Flags().IntVar(&checkpointInterval, "checkpoint-interval", 1000, "Write a checkpoint every N statements")
+	analyzeCmd.Flags().StringVar(&resumeFromPath, "resume", "", "Resume a previous analysis from this checkpoint file")
+	analyzeCmd.Flags().BoolVar(&verifyCheckpointRun, "verify-checkpoint", false, "After resuming, re-analyze the whole file from scratch and confirm the results match")
+	analyzeCmd.Flags().StringVar(&analyzeFsFlag, "fs", "os", "Filesystem backend: os or mem (scheme:// URIs are rejected)")
+	analyzeCmd.Flags().StringVar(&analyzeDialectFlag, "dialect", "mysql", "SQL dialect to parse: mysql, postgres")
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
 	inputFile := args[0]
 
+	fs, err := resolveFS(analyzeFsFlag)
+	if err != nil {
+		return err
+	}
+
 	// Check if input file exists
-	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+	exists, err := afero.Exists(fs, inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to check input file: %w", err)
+	}
+	if !exists {
 		return fmt.Errorf("input file does not exist: %s", inputFile)
 	}
 
 	// Get file info for display
-	fileInfo, err := os.Stat(inputFile)
+	fileInfo, err := fs.Stat(inputFile)
 	if err != nil {
 		return fmt.Errorf("failed to stat input file: %w", err)
 	}
 
-	fmt.Printf("Analyzing SQL file: %s (%.2f MB)\n", inputFile, float64(fileInfo.Size())/(1024*1024))
-	fmt.Println()
+	switch compress.Algorithm(compressionFlag) {
+	case compress.Auto, compress.None, compress.Gzip, compress.Zstd, compress.Snappy:
+	default:
+		return fmt.Errorf("invalid --compression value %q (want auto, none, gzip, zstd, or snappy)", compressionFlag)
+	}
+
+	switch analyzeFormat {
+	case "table", "json", "ndjson", "csv":
+	default:
+		return fmt.Errorf("invalid --format value %q (want table, json, ndjson, or csv)", analyzeFormat)
+	}
+
+	var dialect parser.Dialect
+	switch parser.Dialect(analyzeDialectFlag) {
+	case parser.MySQL, parser.Postgres:
+		dialect = parser.Dialect(analyzeDialectFlag)
+	default:
+		return fmt.Errorf("invalid --dialect value %q (want mysql or postgres)", analyzeDialectFlag)
+	}
+
+	// Machine-readable formats must not be polluted by the human-facing banner
+	if analyzeFormat == "table" {
+		fmt.Printf("Analyzing SQL file: %s (%s)\n", inputFile, bytefmt.Bytes(fileInfo.Size()))
+		fmt.Println()
+	}
+
+	if verifyCheckpointRun && resumeFromPath == "" {
+		return fmt.Errorf("--verify-checkpoint requires --resume")
+	}
+
+	opts := []analyzer.Option{
+		analyzer.WithFS(fs),
+		analyzer.WithCompression(compress.Algorithm(compressionFlag)),
+		analyzer.WithDialect(dialect),
+	}
+
+	if resumeFromPath != "" {
+		cp, err := analyzer.LoadCheckpoint(fs, resumeFromPath)
+		if err != nil {
+			return fmt.Errorf("failed to load checkpoint: %w", err)
+		}
+		opts = append(opts, analyzer.WithResume(cp))
+	}
+
+	if checkpointPath != "" {
+		opts = append(opts, analyzer.WithCheckpoint(checkpointPath, checkpointInterval))
+	}
 
 	// Create analyzer
-	a := analyzer.NewAnalyzer(inputFile)
+	a := analyzer.NewAnalyzer(inputFile, opts...)
 
 	// Start timing
 	startTime := time.Now()
 
 	var stats []*analyzer.TableStats
 
-	// Perform analysis with optional progress
-	if showProgress {
+	// Checkpointing and resuming are both implemented as part of the
+	// progress-reporting code path, since both need to observe progress
+	// through the file
+	useProgressPath := showProgress || checkpointPath != "" || resumeFromPath != ""
+
+	if useProgressPath {
 		var lastProgress int
 		stats, err = a.AnalyzeWithProgress(func(bytesRead int64) {
+			if !showProgress {
+				return
+			}
 			progress := int(float64(bytesRead) / float64(fileInfo.Size()) * 100)
 			if progress > lastProgress && progress%5 == 0 {
 				fmt.Printf("\rProgress: %d%%", progress)
 				lastProgress = progress
 			}
 		})
-		fmt.Println() // New line after progress
+		if showProgress {
+			fmt.Println() // New line after progress
+		}
 	} else {
 		stats, err = a.Analyze()
 	}
@@ -83,8 +199,23 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("analysis failed: %w", err)
 	}
 
+	if verifyCheckpointRun {
+		if err := verifyCheckpointConsistency(fs, inputFile, compressionFlag, stats); err != nil {
+			return err
+		}
+	}
+
 	elapsed := time.Since(startTime)
 
+	switch analyzeFormat {
+	case "json":
+		return printAnalyzeJSON(inputFile, fileInfo.Size(), elapsed, stats)
+	case "ndjson":
+		return printAnalyzeNDJSON(stats)
+	case "csv":
+		return printAnalyzeCSV(stats)
+	}
+
 	// Display results
 	fmt.Printf("✓ Analysis completed in %s\n\n", elapsed.Round(time.Millisecond))
 
@@ -95,26 +226,130 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 
 	// Display table statistics
 	fmt.Printf("Found %d tables:\n\n", len(stats))
-	fmt.Printf("%-40s %12s %12s %12s\n", "Table Name", "INSERTs", "Total Stmts", "Size (MB)")
+	fmt.Printf("%-40s %12s %12s %12s\n", "Table Name", "INSERTs", "Total Stmts", "Size")
 	fmt.Println("─────────────────────────────────────────────────────────────────────────────────")
 
 	var totalInserts int64
 	var totalBytes int64
 
 	for _, stat := range stats {
-		fmt.Printf("%-40s %12d %12d %12.2f\n",
+		fmt.Printf("%-40s %12d %12d %12s\n",
 			truncateString(stat.TableName, 40),
 			stat.InsertCount,
 			stat.StatementCount,
-			float64(stat.TotalBytes)/(1024*1024))
+			bytefmt.Bytes(stat.TotalBytes))
 
 		totalInserts += stat.InsertCount
 		totalBytes += stat.TotalBytes
 	}
 
 	fmt.Println("─────────────────────────────────────────────────────────────────────────────────")
-	fmt.Printf("%-40s %12d %12s %12.2f\n", "TOTAL", totalInserts, "-", float64(totalBytes)/(1024*1024))
+	fmt.Printf("%-40s %12d %12s %12s\n", "TOTAL", totalInserts, "-", bytefmt.Bytes(totalBytes))
+
+	return nil
+}
+
+// printAnalyzeJSON emits a single JSON object summarizing the whole run
+func printAnalyzeJSON(inputFile string, sizeBytes int64, elapsed time.Duration, stats []*analyzer.TableStats) error {
+	out := analyzeOutput{
+		File:      inputFile,
+		SizeBytes: sizeBytes,
+		ElapsedMs: elapsed.Milliseconds(),
+		Tables:    stats,
+	}
+
+	for _, stat := range stats {
+		out.Totals.Inserts += stat.InsertCount
+		out.Totals.Statements += stat.StatementCount
+		out.Totals.Bytes += stat.TotalBytes
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// printAnalyzeNDJSON emits one JSON object per table, flushing after each
+// line so the output can be piped into `jq` or a log pipeline as it's
+// produced
+func printAnalyzeNDJSON(stats []*analyzer.TableStats) error {
+	w := bufio.NewWriter(os.Stdout)
+	enc := json.NewEncoder(w)
+
+	for _, stat := range stats {
+		if err := enc.Encode(stat); err != nil {
+			return fmt.Errorf("failed to encode table stats: %w", err)
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("failed to flush output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// printAnalyzeCSV emits table statistics as CSV with a header row
+func printAnalyzeCSV(stats []*analyzer.TableStats) error {
+	w := csv.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	if err := w.Write([]string{"name", "inserts", "creates", "statements", "bytes"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, stat := range stats {
+		row := []string{
+			stat.TableName,
+			fmt.Sprintf("%d", stat.InsertCount),
+			fmt.Sprintf("%d", stat.CreateCount),
+			fmt.Sprintf("%d", stat.StatementCount),
+			fmt.Sprintf("%d", stat.TotalBytes),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return w.Error()
+}
+
+// verifyCheckpointConsistency re-analyzes inputFile from scratch (ignoring
+// any checkpoint) and confirms the totals match resumedStats exactly. This
+// is the --verify-checkpoint mode: a cheap way to confirm a resumed run
+// didn't double-count or skip statements around the checkpoint boundary.
+func verifyCheckpointConsistency(fs afero.Fs, inputFile, compressionFlag string, resumedStats []*analyzer.TableStats) error {
+	fresh := analyzer.NewAnalyzer(inputFile, analyzer.WithFS(fs), analyzer.WithCompression(compress.Algorithm(compressionFlag)), analyzer.WithDialect(parser.Dialect(analyzeDialectFlag)))
+	freshStats, err := fresh.Analyze()
+	if err != nil {
+		return fmt.Errorf("verify-checkpoint: fresh re-analysis failed: %w", err)
+	}
+
+	resumed := make(map[string]*analyzer.TableStats, len(resumedStats))
+	for _, s := range resumedStats {
+		resumed[s.TableName] = s
+	}
+
+	fresh2 := make(map[string]*analyzer.TableStats, len(freshStats))
+	for _, s := range freshStats {
+		fresh2[s.TableName] = s
+	}
+
+	if len(resumed) != len(fresh2) {
+		return fmt.Errorf("verify-checkpoint: INCONSISTENT - resumed run found %d tables, fresh run found %d", len(resumed), len(fresh2))
+	}
+
+	for name, want := range fresh2 {
+		got, ok := resumed[name]
+		if !ok {
+			return fmt.Errorf("verify-checkpoint: INCONSISTENT - table %q missing from resumed run", name)
+		}
+		if got.InsertCount != want.InsertCount || got.CreateCount != want.CreateCount ||
+			got.StatementCount != want.StatementCount || got.TotalBytes != want.TotalBytes {
+			return fmt.Errorf("verify-checkpoint: INCONSISTENT - table %q: resumed %+v, fresh %+v", name, got, want)
+		}
+	}
 
+	fmt.Println("✓ Checkpoint resume verified consistent with a full re-analysis")
 	return nil
 }
 
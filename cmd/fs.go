@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// resolveFS turns a --fs flag value into a concrete afero.Fs. "os" (the
+// default) reads and writes real files on disk; "mem" keeps everything in
+// memory, useful for --dry-run or tests. A scheme:// URI such as
+// "s3://bucket/prefix" is recognized but always rejected with an error: no
+// remote-object backend (S3, GCS, Azure Blob) is built in.
+func resolveFS(flag string) (afero.Fs, error) {
+	switch {
+	case flag == "" || flag == "os":
+		return afero.NewOsFs(), nil
+	case flag == "mem":
+		return afero.NewMemMapFs(), nil
+	case strings.Contains(flag, "://"):
+		return nil, fmt.Errorf("--fs=%s: remote filesystem backends are not wired in yet (want os or mem)", flag)
+	default:
+		return nil, fmt.Errorf("invalid --fs value %q (want os, mem, or a scheme:// URI)", flag)
+	}
+}
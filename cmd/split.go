@@ -6,16 +6,33 @@ import (
 	"strings"
 	"time"
 
+	"github.com/helgesverre/sql-splitter/internal/bytefmt"
+	"github.com/helgesverre/sql-splitter/internal/compress"
+	"github.com/helgesverre/sql-splitter/internal/parser"
 	"github.com/helgesverre/sql-splitter/internal/splitter"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
 var (
-	outputDir       string
-	verbose         bool
-	dryRun          bool
-	splitProgress   bool
-	tableFilter     string
+	outputDir             string
+	verbose               bool
+	dryRun                bool
+	splitProgress         bool
+	tableFilter           string
+	sessionPreamble       bool
+	splitFsFlag           string
+	splitCompressionFlag  string
+	outputCompressionFlag string
+	outputCompressLevel   int
+	splitWorkers          int
+	splitCheckpointPath   string
+	splitCheckpointIntvl  int
+	splitResume           bool
+	splitReset            bool
+	splitFileSizeFlag     string
+	splitDialectFlag      string
+	splitFormatFlag       string
 )
 
 var splitCmd = &cobra.Command{
@@ -31,7 +48,17 @@ Example:
   sql-splitter split large-dump.sql --output=tables
   sql-splitter split database.sql -o output -v
   sql-splitter split database.sql --tables=users,posts
-  sql-splitter split database.sql --dry-run`,
+  sql-splitter split database.sql --dry-run
+  sql-splitter split database.sql --session-preamble
+  sql-splitter split dump.sql.gz --compression=gzip
+  sql-splitter split dump.sql --compress-output=gzip --compress-level=9
+  sql-splitter split dump.sql --workers=4
+  sql-splitter split huge-dump.sql --checkpoint=dump.checkpoint.json
+  sql-splitter split huge-dump.sql --checkpoint=dump.checkpoint.json --resume
+  sql-splitter split huge-dump.sql --checkpoint=dump.checkpoint.json --resume --reset
+  sql-splitter split huge-dump.sql --file-size=200MB
+  sql-splitter split pg_dump.sql --dialect=postgres
+  sql-splitter split dump.sql --format=csv`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSplit,
 }
@@ -44,32 +71,122 @@ func init() {
 	splitCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview what would be split without writing files")
 	splitCmd.Flags().BoolVarP(&splitProgress, "progress", "p", false, "Show progress during processing")
 	splitCmd.Flags().StringVarP(&tableFilter, "tables", "t", "", "Only split specific tables (comma-separated)")
+	splitCmd.Flags().BoolVar(&sessionPreamble, "session-preamble", false, "Re-emit SET statements and wrap each table file in LOCK/UNLOCK TABLES so it can be restored on its own")
+	splitCmd.Flags().StringVar(&splitFsFlag, "fs", "os", "Filesystem backend: os or mem (scheme:// URIs are rejected)")
+	splitCmd.Flags().StringVar(&splitCompressionFlag, "compression", "auto", "Input compression: auto, none, gzip, zstd, snappy, bzip2, xz")
+	splitCmd.Flags().StringVar(&outputCompressionFlag, "compress-output", "none", "Compress each output table file: none, gzip, zstd")
+	splitCmd.Flags().IntVar(&outputCompressLevel, "compress-level", 0, "Output compression level (algorithm-specific; 0 = default)")
+	splitCmd.Flags().IntVarP(&splitWorkers, "workers", "w", 1, "Number of worker goroutines writing table files concurrently")
+	splitCmd.Flags().StringVar(&splitCheckpointPath, "checkpoint", "", "Periodically write a resumable checkpoint to this path")
+	splitCmd.Flags().IntVar(&splitCheckpointIntvl, "checkpoint-interval", 1000, "Write a checkpoint every N statements")
+	splitCmd.Flags().BoolVar(&splitResume, "resume", false, "Resume from the checkpoint at --checkpoint, if one exists")
+	splitCmd.Flags().BoolVar(&splitReset, "reset", false, "Discard any existing checkpoint at --checkpoint before starting")
+	splitCmd.Flags().StringVarP(&splitFileSizeFlag, "file-size", "F", "", "Rotate each table's output to a new file once it crosses this size, e.g. 200MB (default: no limit)")
+	splitCmd.Flags().StringVar(&splitDialectFlag, "dialect", "mysql", "SQL dialect to parse: mysql, postgres")
+	splitCmd.Flags().StringVar(&splitFormatFlag, "format", "sql", "Output format: sql, csv, jsonl (csv/jsonl route DDL to <table>.schema.sql and transform Insert rows)")
 }
 
 func runSplit(cmd *cobra.Command, args []string) error {
 	inputFile := args[0]
 
+	fs, err := resolveFS(splitFsFlag)
+	if err != nil {
+		return err
+	}
+
 	// Check if input file exists
-	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+	exists, err := afero.Exists(fs, inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to check input file: %w", err)
+	}
+	if !exists {
 		return fmt.Errorf("input file does not exist: %s", inputFile)
 	}
 
 	// Get file info for display
-	fileInfo, err := os.Stat(inputFile)
+	fileInfo, err := fs.Stat(inputFile)
 	if err != nil {
 		return fmt.Errorf("failed to stat input file: %w", err)
 	}
 
+	switch compress.Algorithm(splitCompressionFlag) {
+	case compress.Auto, compress.None, compress.Gzip, compress.Zstd, compress.Snappy, compress.Bzip2, compress.Xz:
+	default:
+		return fmt.Errorf("invalid --compression value %q (want auto, none, gzip, zstd, snappy, bzip2, or xz)", splitCompressionFlag)
+	}
+
+	switch compress.Algorithm(outputCompressionFlag) {
+	case compress.None, compress.Gzip, compress.Zstd, "":
+	default:
+		return fmt.Errorf("invalid --compress-output value %q (want none, gzip, or zstd)", outputCompressionFlag)
+	}
+
+	if splitResume && splitCheckpointPath == "" {
+		return fmt.Errorf("--resume requires --checkpoint")
+	}
+	if splitReset && splitCheckpointPath == "" {
+		return fmt.Errorf("--reset requires --checkpoint")
+	}
+	if splitReset {
+		if err := fs.Remove(splitCheckpointPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to reset checkpoint: %w", err)
+		}
+	}
+
+	var dialect parser.Dialect
+	switch parser.Dialect(splitDialectFlag) {
+	case parser.MySQL, parser.Postgres:
+		dialect = parser.Dialect(splitDialectFlag)
+	default:
+		return fmt.Errorf("invalid --dialect value %q (want mysql or postgres)", splitDialectFlag)
+	}
+
+	var outputFormat splitter.OutputFormat
+	switch splitter.OutputFormat(splitFormatFlag) {
+	case splitter.FormatSQL, splitter.FormatCSV, splitter.FormatJSONL:
+		outputFormat = splitter.OutputFormat(splitFormatFlag)
+	default:
+		return fmt.Errorf("invalid --format value %q (want sql, csv, or jsonl)", splitFormatFlag)
+	}
+
+	var splitFileSize int64
+	if splitFileSizeFlag != "" {
+		splitFileSize, err = bytefmt.ParseSize(splitFileSizeFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --file-size value: %w", err)
+		}
+		if splitFileSize <= 0 {
+			return fmt.Errorf("--file-size must be greater than 0")
+		}
+	}
+
 	if dryRun {
-		fmt.Printf("Dry run: analyzing SQL file: %s (%.2f MB)\n", inputFile, float64(fileInfo.Size())/(1024*1024))
+		fmt.Printf("Dry run: analyzing SQL file: %s (%s)\n", inputFile, bytefmt.Bytes(fileInfo.Size()))
 	} else {
-		fmt.Printf("Splitting SQL file: %s (%.2f MB)\n", inputFile, float64(fileInfo.Size())/(1024*1024))
+		fmt.Printf("Splitting SQL file: %s (%s)\n", inputFile, bytefmt.Bytes(fileInfo.Size()))
 		fmt.Printf("Output directory: %s\n", outputDir)
 	}
 	fmt.Println()
 
 	// Build options
-	var opts []splitter.Option
+	opts := []splitter.Option{
+		splitter.WithFS(fs),
+		splitter.WithCompression(compress.Algorithm(splitCompressionFlag)),
+		splitter.WithOutputCompression(compress.Algorithm(outputCompressionFlag), outputCompressLevel),
+		splitter.WithConcurrency(splitWorkers),
+		splitter.WithDialect(dialect),
+		splitter.WithOutputFormat(outputFormat),
+	}
+
+	if splitCheckpointPath != "" {
+		opts = append(opts, splitter.WithCheckpoint(splitCheckpointPath, splitCheckpointIntvl))
+	}
+	if splitResume {
+		opts = append(opts, splitter.WithResume(true))
+	}
+	if splitFileSize > 0 {
+		opts = append(opts, splitter.WithMaxFileSize(splitFileSize))
+	}
 
 	// Add table filter if specified
 	if tableFilter != "" {
@@ -86,6 +203,11 @@ func runSplit(cmd *cobra.Command, args []string) error {
 		opts = append(opts, splitter.WithDryRun(true))
 	}
 
+	// Wrap each table file so it can be restored independently
+	if sessionPreamble {
+		opts = append(opts, splitter.WithSessionPreamble(true))
+	}
+
 	// Add progress callback if requested
 	if splitProgress {
 		var lastProgress int
@@ -131,10 +253,10 @@ func runSplit(cmd *cobra.Command, args []string) error {
 	fmt.Printf("\nStatistics:\n")
 	fmt.Printf("  Tables found: %d\n", stats.TablesFound)
 	fmt.Printf("  Statements processed: %d\n", stats.StatementsProcessed)
-	fmt.Printf("  Bytes processed: %.2f MB\n", float64(stats.BytesProcessed)/(1024*1024))
+	fmt.Printf("  Bytes processed: %s\n", bytefmt.Bytes(stats.BytesProcessed))
 	fmt.Printf("  Elapsed time: %s\n", elapsed.Round(time.Millisecond))
 	if elapsed.Seconds() > 0 {
-		fmt.Printf("  Throughput: %.2f MB/s\n", float64(stats.BytesProcessed)/(1024*1024)/elapsed.Seconds())
+		fmt.Printf("  Throughput: %s\n", bytefmt.Rate(float64(stats.BytesProcessed)/elapsed.Seconds()))
 	}
 
 	if verbose && !dryRun {
@@ -1,43 +1,121 @@
 package analyzer
 
 import (
+	"bufio"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"sync"
 
+	"github.com/helgesverre/sql-splitter/internal/compress"
 	"github.com/helgesverre/sql-splitter/internal/parser"
+	"github.com/spf13/afero"
 )
 
 // TableStats holds statistics for a single table
 type TableStats struct {
-	TableName      string
-	InsertCount    int64
-	CreateCount    int64
-	TotalBytes     int64
-	StatementCount int64
+	TableName string `json:"name"`
+	// Database is the name most recently set by a USE statement before this
+	// table was touched. Only populated by the sequential Analyze and
+	// AnalyzeWithProgress paths - AnalyzeWithOptions processes statements out
+	// of order across worker shards, so it cannot attribute a database.
+	Database         string `json:"database,omitempty"`
+	InsertCount      int64  `json:"inserts"`
+	CreateCount      int64  `json:"creates"`
+	TotalBytes       int64  `json:"bytes"`
+	StatementCount   int64  `json:"statements"`
+	TransactionCount int64  `json:"transactions,omitempty"`
 }
 
 // Analyzer analyzes SQL files to gather statistics
 type Analyzer struct {
-	inputFile string
-	stats     map[string]*TableStats
-	mu        sync.RWMutex
+	fs          afero.Fs
+	inputFile   string
+	compression compress.Algorithm
+	stats       map[string]*TableStats
+	mu          sync.RWMutex
+
+	checkpointPath     string
+	checkpointInterval int
+	resumeFrom         *Checkpoint
+
+	dialect parser.Dialect
+}
+
+// Option is a functional option for configuring an Analyzer
+type Option func(*Analyzer)
+
+// WithCompression sets the input decompression algorithm. Defaults to
+// compress.Auto, which sniffs magic bytes and falls back to the file
+// extension.
+func WithCompression(algo compress.Algorithm) Option {
+	return func(a *Analyzer) {
+		a.compression = algo
+	}
+}
+
+// WithCheckpoint makes AnalyzeWithProgress periodically write a resumable
+// checkpoint to path every interval statements (default 1000 when <= 0).
+// The checkpoint is only ever written between complete statements.
+func WithCheckpoint(path string, interval int) Option {
+	if interval <= 0 {
+		interval = 1000
+	}
+	return func(a *Analyzer) {
+		a.checkpointPath = path
+		a.checkpointInterval = interval
+	}
+}
+
+// WithResume seeds the analyzer from a previously written checkpoint:
+// AnalyzeWithProgress seeks the input file to cp.Offset and restores
+// cp.Stats before continuing. Only supported for uncompressed input,
+// since a compressed stream generally can't be seeked mid-stream.
+func WithResume(cp *Checkpoint) Option {
+	return func(a *Analyzer) {
+		a.resumeFrom = cp
+	}
+}
+
+// WithFS sets the filesystem the input file (and any checkpoint file) is
+// read from. Defaults to afero.NewOsFs(); pass afero.NewMemMapFs() for
+// tests, or a remote-object-store afero.Fs to analyze a dump directly out
+// of a bucket.
+func WithFS(fs afero.Fs) Option {
+	return func(a *Analyzer) {
+		a.fs = fs
+	}
+}
+
+// WithDialect sets the SQL dialect used to parse the input. Defaults to
+// parser.MySQL; pass parser.Postgres to analyze pg_dump output.
+func WithDialect(d parser.Dialect) Option {
+	return func(a *Analyzer) {
+		a.dialect = d
+	}
 }
 
 // NewAnalyzer creates a new SQL file analyzer
-func NewAnalyzer(inputFile string) *Analyzer {
-	return &Analyzer{
-		inputFile: inputFile,
-		stats:     make(map[string]*TableStats),
+func NewAnalyzer(inputFile string, opts ...Option) *Analyzer {
+	a := &Analyzer{
+		fs:          afero.NewOsFs(),
+		inputFile:   inputFile,
+		compression: compress.Auto,
+		stats:       make(map[string]*TableStats),
+		dialect:     parser.MySQL,
+	}
+
+	for _, opt := range opts {
+		opt(a)
 	}
+
+	return a
 }
 
 // Analyze performs the analysis and returns statistics sorted by insert count
 func (a *Analyzer) Analyze() ([]*TableStats, error) {
 	// Open input file
-	file, err := os.Open(a.inputFile)
+	file, err := a.fs.Open(a.inputFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open input file: %w", err)
 	}
@@ -49,9 +127,22 @@ func (a *Analyzer) Analyze() ([]*TableStats, error) {
 		return nil, fmt.Errorf("failed to stat input file: %w", err)
 	}
 
-	// Create parser with optimal buffer size
+	// Wrap with transparent decompression (auto-detected by default)
+	reader, err := compress.Wrap(file, a.compression, a.inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up decompression: %w", err)
+	}
+
+	// Create parser with optimal buffer size (sized off the on-disk,
+	// compressed byte count; the parser's own buffering absorbs the
+	// difference in throughput once decompressed)
 	bufferSize := parser.DetermineBufferSize(fileInfo.Size())
-	p := parser.NewParser(file, bufferSize)
+	p := parser.NewParser(reader, bufferSize, parser.WithDialect(a.dialect))
+
+	// currentDatabase and inTransaction track session state carried across
+	// statements by USE and BEGIN/COMMIT/ROLLBACK - see updateStats.
+	var currentDatabase string
+	var inTransaction bool
 
 	// Process statements
 	for {
@@ -66,21 +157,47 @@ func (a *Analyzer) Analyze() ([]*TableStats, error) {
 		// Parse statement type and table name
 		stmtType, tableName := p.ParseStatement(stmt)
 
-		// Skip unknown statements or those without table names
-		if stmtType == parser.Unknown || tableName == "" {
+		switch stmtType {
+		case parser.Use:
+			currentDatabase = tableName
+		case parser.Begin:
+			inTransaction = true
+		case parser.Commit, parser.Rollback:
+			inTransaction = false
+		}
+
+		// Skip statements that don't represent a real table (see
+		// isNonTableStatement) or carry no name at all.
+		if isNonTableStatement(stmtType) || tableName == "" {
 			continue
 		}
 
 		// Update statistics
-		a.updateStats(tableName, stmtType, int64(len(stmt)))
+		a.updateStats(tableName, stmtType, int64(len(stmt)), currentDatabase, inTransaction)
 	}
 
 	// Convert map to sorted slice
 	return a.getSortedStats(), nil
 }
 
-// updateStats updates statistics for a table
-func (a *Analyzer) updateStats(tableName string, stmtType parser.StatementType, bytes int64) {
+// isNonTableStatement reports whether stmtType can never correspond to a
+// real table, even though ParseStatement may return a non-empty name for
+// it (a database, or a trigger/function/procedure/view name) - so it must
+// not be allowed to seed a TableStats entry.
+func isNonTableStatement(stmtType parser.StatementType) bool {
+	switch stmtType {
+	case parser.Unknown, parser.Use, parser.LockTables,
+		parser.CreateTrigger, parser.CreateFunction, parser.CreateProcedure, parser.CreateView:
+		return true
+	default:
+		return false
+	}
+}
+
+// updateStats updates statistics for a table. database and inTransaction
+// reflect the session state (USE / BEGIN.../COMMIT|ROLLBACK) accumulated by
+// the caller up to this statement.
+func (a *Analyzer) updateStats(tableName string, stmtType parser.StatementType, bytes int64, database string, inTransaction bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -94,6 +211,12 @@ func (a *Analyzer) updateStats(tableName string, stmtType parser.StatementType,
 
 	stats.StatementCount++
 	stats.TotalBytes += bytes
+	if database != "" {
+		stats.Database = database
+	}
+	if inTransaction && stmtType == parser.Insert {
+		stats.TransactionCount++
+	}
 
 	switch stmtType {
 	case parser.CreateTable:
@@ -125,7 +248,7 @@ func (a *Analyzer) getSortedStats() []*TableStats {
 // AnalyzeWithProgress performs analysis with progress callback
 func (a *Analyzer) AnalyzeWithProgress(progressFn func(bytesRead int64)) ([]*TableStats, error) {
 	// Open input file
-	file, err := os.Open(a.inputFile)
+	file, err := a.fs.Open(a.inputFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open input file: %w", err)
 	}
@@ -137,18 +260,61 @@ func (a *Analyzer) AnalyzeWithProgress(progressFn func(bytesRead int64)) ([]*Tab
 		return nil, fmt.Errorf("failed to stat input file: %w", err)
 	}
 
-	// Wrap file with progress reader
+	// If resuming, seek past the already-processed prefix and seed the
+	// in-memory stats from the checkpoint before reading another byte
+	var startOffset int64
+	if a.resumeFrom != nil {
+		// Auto doesn't itself name a compressed codec - resolve it by
+		// sniffing the untouched start of the file before seeking, so a
+		// plain .sql file (the common case) isn't rejected just because
+		// compression defaults to Auto.
+		resolvedCompression := a.compression
+		if resolvedCompression == compress.Auto {
+			sniffed, err := compress.Detect(bufio.NewReader(file), a.inputFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to sniff input compression: %w", err)
+			}
+			resolvedCompression = sniffed
+		}
+		if resolvedCompression != compress.None && resolvedCompression != "" {
+			return nil, fmt.Errorf("analyzer: --resume requires --compression=none (cannot seek within a compressed stream)")
+		}
+
+		startOffset = a.resumeFrom.Offset
+		if _, err := file.Seek(startOffset, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to seek to checkpoint offset: %w", err)
+		}
+
+		a.mu.Lock()
+		for tableName, stats := range a.resumeFrom.Stats {
+			a.stats[tableName] = stats
+		}
+		a.mu.Unlock()
+	}
+
+	// Wrap file with progress reader first, so progress is reported against
+	// the compressed on-disk byte count rather than the decompressed stream
 	progressReader := &progressReader{
 		reader:     file,
 		callback:   progressFn,
 		totalBytes: fileInfo.Size(),
+		readBytes:  startOffset,
+	}
+
+	// Wrap with transparent decompression (auto-detected by default)
+	reader, err := compress.Wrap(progressReader, a.compression, a.inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up decompression: %w", err)
 	}
 
 	// Create parser with optimal buffer size
 	bufferSize := parser.DetermineBufferSize(fileInfo.Size())
-	p := parser.NewParser(progressReader, bufferSize)
+	p := parser.NewParser(reader, bufferSize, parser.WithDialect(a.dialect))
 
 	// Process statements
+	var currentDatabase string
+	var inTransaction bool
+	statementsSinceCheckpoint := 0
 	for {
 		stmt, err := p.ReadStatement()
 		if err != nil {
@@ -161,19 +327,196 @@ func (a *Analyzer) AnalyzeWithProgress(progressFn func(bytesRead int64)) ([]*Tab
 		// Parse statement type and table name
 		stmtType, tableName := p.ParseStatement(stmt)
 
-		// Skip unknown statements or those without table names
-		if stmtType == parser.Unknown || tableName == "" {
+		switch stmtType {
+		case parser.Use:
+			currentDatabase = tableName
+		case parser.Begin:
+			inTransaction = true
+		case parser.Commit, parser.Rollback:
+			inTransaction = false
+		}
+
+		// Skip statements that don't represent a real table (see
+		// isNonTableStatement) or carry no name at all.
+		if isNonTableStatement(stmtType) || tableName == "" {
 			continue
 		}
 
 		// Update statistics
-		a.updateStats(tableName, stmtType, int64(len(stmt)))
+		a.updateStats(tableName, stmtType, int64(len(stmt)), currentDatabase, inTransaction)
+
+		// Checkpoints are only ever written here, between ReadStatement
+		// calls - never from inside ReadStatement itself - so the offset
+		// always lands on a complete statement boundary.
+		if a.checkpointPath != "" {
+			statementsSinceCheckpoint++
+			if statementsSinceCheckpoint >= a.checkpointInterval {
+				statementsSinceCheckpoint = 0
+
+				a.mu.RLock()
+				cpErr := WriteCheckpoint(a.fs, a.checkpointPath, startOffset+p.BytesConsumed(), a.stats)
+				a.mu.RUnlock()
+
+				if cpErr != nil {
+					return nil, fmt.Errorf("failed to write checkpoint: %w", cpErr)
+				}
+			}
+		}
+	}
+
+	// Write a final checkpoint so --resume reflects a clean completion
+	if a.checkpointPath != "" {
+		a.mu.RLock()
+		cpErr := WriteCheckpoint(a.fs, a.checkpointPath, startOffset+p.BytesConsumed(), a.stats)
+		a.mu.RUnlock()
+		if cpErr != nil {
+			return nil, fmt.Errorf("failed to write final checkpoint: %w", cpErr)
+		}
 	}
 
 	// Convert map to sorted slice
 	return a.getSortedStats(), nil
 }
 
+// AnalyzeOptions configures a parallel analysis run.
+type AnalyzeOptions struct {
+	// Workers is the number of ParseStatement worker goroutines. Values
+	// < 1 are treated as 1; the reader goroutine and worker pipeline is used
+	// even with a single worker.
+	Workers int
+	// QueueDepth is the size of the bounded channel between the reader
+	// goroutine and the workers. Defaults to 2*Workers when <= 0.
+	QueueDepth int
+}
+
+// AnalyzeWithOptions analyzes the file using a producer/consumer pipeline:
+// a single reader goroutine calls Parser.ReadStatement and pushes raw
+// statement bytes onto a bounded channel, while opts.Workers goroutines call
+// Parser.ParseStatement concurrently, each accumulating into its own
+// TableStats shard. A final reducer merges the shards into a.stats under
+// a.mu. This parallelizes the CPU-bound classification work while keeping
+// statement reading single-threaded and in source order.
+func (a *Analyzer) AnalyzeWithOptions(opts AnalyzeOptions) ([]*TableStats, error) {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	queueDepth := opts.QueueDepth
+	if queueDepth <= 0 {
+		queueDepth = workers * 2
+	}
+
+	// Open input file
+	file, err := a.fs.Open(a.inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer file.Close()
+
+	// Get file size for buffer optimization
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat input file: %w", err)
+	}
+
+	// Wrap with transparent decompression (auto-detected by default)
+	reader, err := compress.Wrap(file, a.compression, a.inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up decompression: %w", err)
+	}
+
+	// Create parser with optimal buffer size
+	bufferSize := parser.DetermineBufferSize(fileInfo.Size())
+	p := parser.NewParser(reader, bufferSize, parser.WithDialect(a.dialect))
+
+	stmtCh := make(chan []byte, queueDepth)
+	shards := make([]map[string]*TableStats, workers)
+	for i := range shards {
+		shards[i] = make(map[string]*TableStats)
+	}
+
+	var readErr error
+	var readWg sync.WaitGroup
+	readWg.Add(1)
+	go func() {
+		defer readWg.Done()
+		defer close(stmtCh)
+
+		for {
+			stmt, err := p.ReadStatement()
+			if err != nil {
+				if err != io.EOF {
+					readErr = fmt.Errorf("error reading statement: %w", err)
+				}
+				return
+			}
+			stmtCh <- stmt
+		}
+	}()
+
+	var workerWg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		workerWg.Add(1)
+		go func(shard map[string]*TableStats) {
+			defer workerWg.Done()
+			for stmt := range stmtCh {
+				stmtType, tableName := p.ParseStatement(stmt)
+				if isNonTableStatement(stmtType) || tableName == "" {
+					continue
+				}
+				updateShardStats(shard, tableName, stmtType, int64(len(stmt)))
+			}
+		}(shards[i])
+	}
+
+	workerWg.Wait()
+	readWg.Wait()
+
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	// Merge per-worker shards into a.stats
+	a.mu.Lock()
+	for _, shard := range shards {
+		for tableName, shardStats := range shard {
+			stats, exists := a.stats[tableName]
+			if !exists {
+				a.stats[tableName] = shardStats
+				continue
+			}
+			stats.InsertCount += shardStats.InsertCount
+			stats.CreateCount += shardStats.CreateCount
+			stats.TotalBytes += shardStats.TotalBytes
+			stats.StatementCount += shardStats.StatementCount
+		}
+	}
+	a.mu.Unlock()
+
+	return a.getSortedStats(), nil
+}
+
+// updateShardStats updates statistics for a table within a worker-owned
+// shard (no locking required - each shard is only ever touched by one
+// worker goroutine).
+func updateShardStats(shard map[string]*TableStats, tableName string, stmtType parser.StatementType, bytes int64) {
+	stats, exists := shard[tableName]
+	if !exists {
+		stats = &TableStats{TableName: tableName}
+		shard[tableName] = stats
+	}
+
+	stats.StatementCount++
+	stats.TotalBytes += bytes
+
+	switch stmtType {
+	case parser.CreateTable:
+		stats.CreateCount++
+	case parser.Insert:
+		stats.InsertCount++
+	}
+}
+
 // progressReader wraps an io.Reader to track progress
 type progressReader struct {
 	reader     io.Reader
@@ -1,9 +1,13 @@
 package analyzer
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/spf13/afero"
 )
 
 func TestAnalyzer_Analyze(t *testing.T) {
@@ -54,6 +58,39 @@ INSERT INTO posts VALUES (1, 1, 'Hello World');
 	}
 }
 
+func TestAnalyzer_Analyze_SkipsStoredRoutines(t *testing.T) {
+	// CREATE TRIGGER/FUNCTION/PROCEDURE/VIEW carry a non-empty object name,
+	// but they aren't tables and must not seed a phantom TableStats entry.
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.sql")
+
+	content := "CREATE TABLE users (id INT);\n" +
+		"INSERT INTO users VALUES (1);\n" +
+		"DELIMITER $$\n" +
+		"CREATE TRIGGER before_insert_users BEFORE INSERT ON users FOR EACH ROW BEGIN SET NEW.created_at = NOW(); END$$\n" +
+		"CREATE FUNCTION double_it(n INT) RETURNS INT DETERMINISTIC RETURN n * 2$$\n" +
+		"CREATE PROCEDURE add_user(IN name VARCHAR(255)) BEGIN INSERT INTO users (name) VALUES (name); END$$\n" +
+		"DELIMITER ;\n" +
+		"CREATE VIEW active_users AS SELECT * FROM users;\n"
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	a := NewAnalyzer(testFile)
+	stats, err := a.Analyze()
+	if err != nil {
+		t.Fatalf("analyze failed: %v", err)
+	}
+
+	if len(stats) != 1 {
+		t.Fatalf("expected only the 'users' table, got %d: %v", len(stats), stats)
+	}
+	if stats[0].TableName != "users" {
+		t.Errorf("expected table 'users', got %q", stats[0].TableName)
+	}
+}
+
 func TestAnalyzer_AnalyzeWithProgress(t *testing.T) {
 	// Create temp file with SQL content
 	tmpDir := t.TempDir()
@@ -197,6 +234,48 @@ func TestAnalyzer_TableWithBackticks(t *testing.T) {
 	}
 }
 
+func TestAnalyzer_AnalyzeWithOptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.sql")
+
+	var content strings.Builder
+	content.WriteString("CREATE TABLE users (id INT);\n")
+	for i := 0; i < 200; i++ {
+		content.WriteString("INSERT INTO users VALUES (1);\n")
+	}
+	content.WriteString("CREATE TABLE posts (id INT);\n")
+	for i := 0; i < 100; i++ {
+		content.WriteString("INSERT INTO posts VALUES (1);\n")
+	}
+
+	if err := os.WriteFile(testFile, []byte(content.String()), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	a := NewAnalyzer(testFile)
+	stats, err := a.AnalyzeWithOptions(AnalyzeOptions{Workers: 4})
+	if err != nil {
+		t.Fatalf("analyze failed: %v", err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(stats))
+	}
+
+	if stats[0].TableName != "users" {
+		t.Errorf("expected first table to be 'users', got %q", stats[0].TableName)
+	}
+	if stats[0].InsertCount != 200 {
+		t.Errorf("expected users to have 200 inserts, got %d", stats[0].InsertCount)
+	}
+	if stats[1].TableName != "posts" {
+		t.Errorf("expected second table to be 'posts', got %q", stats[1].TableName)
+	}
+	if stats[1].InsertCount != 100 {
+		t.Errorf("expected posts to have 100 inserts, got %d", stats[1].InsertCount)
+	}
+}
+
 func BenchmarkAnalyzer_Analyze(b *testing.B) {
 	tmpDir := b.TempDir()
 	testFile := filepath.Join(tmpDir, "bench.sql")
@@ -223,3 +302,140 @@ func BenchmarkAnalyzer_Analyze(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkAnalyzer_AnalyzeWithOptions(b *testing.B) {
+	tmpDir := b.TempDir()
+	testFile := filepath.Join(tmpDir, "bench.sql")
+
+	var content strings.Builder
+	content.WriteString("CREATE TABLE users (id INT);\n")
+	for i := 0; i < 200000; i++ {
+		content.WriteString("INSERT INTO users VALUES (1, 'some row data here');\n")
+	}
+
+	if err := os.WriteFile(testFile, []byte(content.String()), 0644); err != nil {
+		b.Fatalf("failed to write test file: %v", err)
+	}
+
+	workerCounts := []int{1, 2, 4, 8}
+
+	for _, workers := range workerCounts {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(int64(content.Len()))
+
+			for i := 0; i < b.N; i++ {
+				a := NewAnalyzer(testFile)
+				_, err := a.AnalyzeWithOptions(AnalyzeOptions{Workers: workers})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyzer_CheckpointResume(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	testFile := "/test.sql"
+	checkpointFile := "/checkpoint.json"
+
+	var content strings.Builder
+	content.WriteString("CREATE TABLE users (id INT);\n")
+	for i := 0; i < 50; i++ {
+		content.WriteString("INSERT INTO users VALUES (1);\n")
+	}
+
+	if err := afero.WriteFile(fs, testFile, []byte(content.String()), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	// Run with checkpointing enabled, writing one every 10 statements
+	a := NewAnalyzer(testFile, WithFS(fs), WithCheckpoint(checkpointFile, 10))
+	fullStats, err := a.AnalyzeWithProgress(func(int64) {})
+	if err != nil {
+		t.Fatalf("analyze failed: %v", err)
+	}
+
+	if exists, err := afero.Exists(fs, checkpointFile); err != nil || !exists {
+		t.Fatalf("expected checkpoint file to be written: exists=%v err=%v", exists, err)
+	}
+
+	cp, err := LoadCheckpoint(fs, checkpointFile)
+	if err != nil {
+		t.Fatalf("failed to load checkpoint: %v", err)
+	}
+
+	// Resuming from the final checkpoint (the whole file already consumed)
+	// should reproduce identical stats without reading any more statements
+	resumed := NewAnalyzer(testFile, WithFS(fs), WithResume(cp))
+	resumedStats, err := resumed.AnalyzeWithProgress(func(int64) {})
+	if err != nil {
+		t.Fatalf("resumed analyze failed: %v", err)
+	}
+
+	if len(resumedStats) != len(fullStats) {
+		t.Fatalf("expected %d tables after resume, got %d", len(fullStats), len(resumedStats))
+	}
+	if resumedStats[0].InsertCount != fullStats[0].InsertCount {
+		t.Errorf("expected %d inserts after resume, got %d", fullStats[0].InsertCount, resumedStats[0].InsertCount)
+	}
+}
+
+func TestAnalyzer_ResumeRejectsCompression(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.sql.gz")
+	if err := os.WriteFile(testFile, []byte{0x1f, 0x8b}, 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	a := NewAnalyzer(testFile, WithResume(&Checkpoint{Offset: 0, Stats: map[string]*TableStats{}}))
+	_, err := a.AnalyzeWithProgress(func(int64) {})
+	if err == nil {
+		t.Error("expected an error resuming a compressed input")
+	}
+}
+
+func TestAnalyzer_TransactionAndSessionAwareness(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.sql")
+
+	content := `USE shop;
+CREATE TABLE orders (id INT);
+BEGIN;
+ALTER TABLE orders ADD COLUMN note VARCHAR(255);
+INSERT INTO orders VALUES (1);
+INSERT INTO orders VALUES (2);
+COMMIT;
+INSERT INTO orders VALUES (3);
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	a := NewAnalyzer(testFile)
+	stats, err := a.Analyze()
+	if err != nil {
+		t.Fatalf("analyze failed: %v", err)
+	}
+
+	if len(stats) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(stats))
+	}
+
+	orders := stats[0]
+	if orders.TableName != "orders" {
+		t.Fatalf("expected 'orders', got %q", orders.TableName)
+	}
+	if orders.Database != "shop" {
+		t.Errorf("expected database 'shop', got %q", orders.Database)
+	}
+	// Two inserts happened inside BEGIN/COMMIT (the ALTER TABLE in between
+	// must not count), one happened after
+	if orders.TransactionCount != 2 {
+		t.Errorf("expected 2 inserts inside a transaction, got %d", orders.TransactionCount)
+	}
+	if orders.InsertCount != 3 {
+		t.Errorf("expected 3 inserts, got %d", orders.InsertCount)
+	}
+}
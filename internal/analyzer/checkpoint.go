@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/afero"
+)
+
+// Checkpoint captures enough state to resume a long-running Analyze after
+// an interruption: the byte offset into the input file and the
+// accumulated per-table stats at that point. It is only ever written
+// between complete statements (see AnalyzeWithProgress), so Offset never
+// lands mid-quote or mid-escape.
+type Checkpoint struct {
+	Offset int64                  `json:"offset"`
+	Stats  map[string]*TableStats `json:"stats"`
+}
+
+// WriteCheckpoint writes cp to path on fs, replacing any existing file
+// atomically so a crash mid-write never leaves a corrupt checkpoint.
+func WriteCheckpoint(fs afero.Fs, path string, offset int64, stats map[string]*TableStats) error {
+	data, err := json.Marshal(Checkpoint{Offset: offset, Stats: stats})
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := afero.WriteFile(fs, tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := fs.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to finalize checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// LoadCheckpoint reads a checkpoint previously written by WriteCheckpoint.
+func LoadCheckpoint(fs afero.Fs, path string) (*Checkpoint, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+
+	return &cp, nil
+}
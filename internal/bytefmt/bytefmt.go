@@ -0,0 +1,82 @@
+// Package bytefmt renders byte counts and byte rates as human-readable
+// strings (e.g. "1.23 GB", "456.70 MB/s") for CLI output, picking the
+// largest unit under which the value is still >= 1.
+package bytefmt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	kb = 1024
+	mb = kb * 1024
+	gb = mb * 1024
+	tb = gb * 1024
+)
+
+// Bytes renders n as a human-readable byte count, e.g. "1.23 GB". Values
+// under 1 KB are rendered as a plain integer byte count, e.g. "512 B".
+func Bytes(n int64) string {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+
+	switch {
+	case abs >= tb:
+		return fmt.Sprintf("%.2f TB", float64(n)/tb)
+	case abs >= gb:
+		return fmt.Sprintf("%.2f GB", float64(n)/gb)
+	case abs >= mb:
+		return fmt.Sprintf("%.2f MB", float64(n)/mb)
+	case abs >= kb:
+		return fmt.Sprintf("%.2f KB", float64(n)/kb)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// Rate renders a throughput of n bytes per second, e.g. "456.70 MB/s".
+func Rate(bytesPerSecond float64) string {
+	return Bytes(int64(bytesPerSecond)) + "/s"
+}
+
+// ParseSize parses a human-readable byte size like "200B", "1.5MB", or
+// "10GB" (case-insensitive, whitespace-tolerant) into a byte count. A bare
+// number with no unit is interpreted as bytes. This is the inverse of
+// Bytes and accepts the same KB/MB/GB/TB units (1024-based).
+func ParseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("bytefmt: empty size")
+	}
+
+	upper := strings.ToUpper(s)
+	unit := int64(1)
+	numPart := upper
+
+	switch {
+	case strings.HasSuffix(upper, "TB"):
+		unit, numPart = tb, upper[:len(upper)-2]
+	case strings.HasSuffix(upper, "GB"):
+		unit, numPart = gb, upper[:len(upper)-2]
+	case strings.HasSuffix(upper, "MB"):
+		unit, numPart = mb, upper[:len(upper)-2]
+	case strings.HasSuffix(upper, "KB"):
+		unit, numPart = kb, upper[:len(upper)-2]
+	case strings.HasSuffix(upper, "B"):
+		unit, numPart = 1, upper[:len(upper)-1]
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(numPart), 64)
+	if err != nil {
+		return 0, fmt.Errorf("bytefmt: invalid size %q: %w", s, err)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("bytefmt: size %q cannot be negative", s)
+	}
+
+	return int64(value * float64(unit)), nil
+}
@@ -0,0 +1,82 @@
+package bytefmt
+
+import "testing"
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int64
+		want string
+	}{
+		{"zero", 0, "0 B"},
+		{"bytes", 512, "512 B"},
+		{"exactly one KB", 1024, "1.00 KB"},
+		{"kilobytes", 1536, "1.50 KB"},
+		{"megabytes", 5 * 1024 * 1024, "5.00 MB"},
+		{"gigabytes", int64(2.5 * 1024 * 1024 * 1024), "2.50 GB"},
+		{"terabytes", 3 * 1024 * 1024 * 1024 * 1024, "3.00 TB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Bytes(tt.n); got != tt.want {
+				t.Errorf("Bytes(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		name           string
+		bytesPerSecond float64
+		want           string
+	}{
+		{"small", 1024, "1.00 KB/s"},
+		{"large", 200 * 1024 * 1024, "200.00 MB/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Rate(tt.bytesPerSecond); got != tt.want {
+				t.Errorf("Rate(%v) = %q, want %q", tt.bytesPerSecond, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int64
+	}{
+		{"bare bytes", "512", 512},
+		{"bytes suffix", "200B", 200},
+		{"kilobytes", "1KB", 1024},
+		{"megabytes", "200MB", 200 * 1024 * 1024},
+		{"gigabytes", "1.5GB", int64(1.5 * 1024 * 1024 * 1024)},
+		{"lowercase", "10mb", 10 * 1024 * 1024},
+		{"whitespace", "  10 MB  ", 10 * 1024 * 1024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSize(tt.in)
+			if err != nil {
+				t.Fatalf("ParseSize(%q) returned error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSize(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSize_Errors(t *testing.T) {
+	for _, in := range []string{"", "abc", "-5MB"} {
+		if _, err := ParseSize(in); err == nil {
+			t.Errorf("ParseSize(%q) expected an error, got nil", in)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Package compress provides transparent decompression of SQL dump streams
+// (typically produced by piping `mysqldump` through gzip/zstd/snappy/bzip2/
+// xz) and, for a subset of codecs, compression of output streams.
+package compress
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Algorithm identifies a supported (de)compression codec.
+type Algorithm string
+
+const (
+	// Auto sniffs the stream's magic bytes, falling back to the file
+	// extension, to pick a concrete algorithm.
+	Auto   Algorithm = "auto"
+	None   Algorithm = "none"
+	Gzip   Algorithm = "gzip"
+	Zstd   Algorithm = "zstd"
+	Snappy Algorithm = "snappy"
+	// Bzip2 and Xz are input-only: the standard library's bzip2 reader (and
+	// the xz package we use) don't expose a writer, so these never appear
+	// as an output compression choice.
+	Bzip2 Algorithm = "bzip2"
+	Xz    Algorithm = "xz"
+)
+
+var (
+	gzipMagic   = []byte{0x1f, 0x8b}
+	zstdMagic   = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	snappyMagic = []byte{0xff, 0x06, 0x00, 0x00, 's', 'N', 'a', 'P', 'p', 'Y'}
+	bzip2Magic  = []byte{'B', 'Z', 'h'}
+	xzMagic     = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+)
+
+// Detect sniffs the first bytes of r, falling back to the extension of name,
+// to determine which algorithm produced the stream. It never returns Auto;
+// an unrecognized stream resolves to None.
+func Detect(r *bufio.Reader, name string) (Algorithm, error) {
+	magic, err := r.Peek(len(snappyMagic))
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return None, err
+	}
+
+	switch {
+	case hasPrefix(magic, zstdMagic):
+		return Zstd, nil
+	case hasPrefix(magic, gzipMagic):
+		return Gzip, nil
+	case hasPrefix(magic, xzMagic):
+		return Xz, nil
+	case hasPrefix(magic, bzip2Magic):
+		return Bzip2, nil
+	case hasPrefix(magic, snappyMagic):
+		return Snappy, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".gz", ".gzip":
+		return Gzip, nil
+	case ".zst", ".zstd":
+		return Zstd, nil
+	case ".sz", ".snappy":
+		return Snappy, nil
+	case ".bz2":
+		return Bzip2, nil
+	case ".xz":
+		return Xz, nil
+	}
+
+	return None, nil
+}
+
+func hasPrefix(b, prefix []byte) bool {
+	if len(b) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if b[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Wrap returns an io.Reader that transparently decompresses r according to
+// algo. If algo is Auto, the first bytes of r (and the extension of name)
+// are sniffed to pick a concrete algorithm; name is otherwise unused. None
+// (including an auto-detected absence of a known magic number) returns r
+// wrapped in a *bufio.Reader unchanged.
+func Wrap(r io.Reader, algo Algorithm, name string) (io.Reader, error) {
+	br := bufio.NewReader(r)
+
+	if algo == Auto {
+		detected, err := Detect(br, name)
+		if err != nil {
+			return nil, fmt.Errorf("compress: failed to sniff %s: %w", name, err)
+		}
+		algo = detected
+	}
+
+	switch algo {
+	case Gzip:
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("compress: failed to open gzip stream: %w", err)
+		}
+		return gr, nil
+	case Zstd:
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("compress: failed to open zstd stream: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	case Snappy:
+		return snappy.NewReader(br), nil
+	case Bzip2:
+		return bzip2.NewReader(br), nil
+	case Xz:
+		xr, err := xz.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("compress: failed to open xz stream: %w", err)
+		}
+		return xr, nil
+	case None, "":
+		return br, nil
+	default:
+		return nil, fmt.Errorf("compress: unsupported algorithm %q", algo)
+	}
+}
+
+// WrapWriter returns an io.WriteCloser that transparently compresses writes
+// to w according to algo, at the given level (algorithm-specific; 0 means
+// the algorithm's default). Closing the returned writer flushes and
+// finalizes the compressed stream, but does not close w itself. Only Gzip,
+// Zstd, and None are supported as output codecs - Bzip2 and Xz are
+// input-only (see Algorithm).
+func WrapWriter(w io.Writer, algo Algorithm, level int) (io.WriteCloser, error) {
+	switch algo {
+	case Gzip:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		gw, err := gzip.NewWriterLevel(w, level)
+		if err != nil {
+			return nil, fmt.Errorf("compress: failed to open gzip writer: %w", err)
+		}
+		return gw, nil
+	case Zstd:
+		opts := []zstd.EOption{}
+		if level != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		zw, err := zstd.NewWriter(w, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("compress: failed to open zstd writer: %w", err)
+		}
+		return zw, nil
+	case None, "":
+		return nopWriteCloser{w}, nil
+	default:
+		return nil, fmt.Errorf("compress: unsupported output algorithm %q", algo)
+	}
+}
+
+// nopWriteCloser adapts an io.Writer that doesn't need flushing/finalizing
+// (the None codec) to the io.WriteCloser shape WrapWriter always returns.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// Extension returns the filename suffix conventionally used for algo's
+// compressed output (e.g. ".gz" for Gzip), or "" for None/unrecognized
+// algorithms.
+func Extension(algo Algorithm) string {
+	switch algo {
+	case Gzip:
+		return ".gz"
+	case Zstd:
+		return ".zst"
+	case Snappy:
+		return ".sz"
+	case Bzip2:
+		return ".bz2"
+	case Xz:
+		return ".xz"
+	default:
+		return ""
+	}
+}
@@ -70,6 +70,117 @@ func TestParser_ReadStatement(t *testing.T) {
 	}
 }
 
+func TestParser_ReadStatement_DelimiterDirective(t *testing.T) {
+	input := "DELIMITER $$\n" +
+		"CREATE TRIGGER before_insert_users BEFORE INSERT ON users FOR EACH ROW BEGIN SET NEW.created_at = NOW(); END$$\n" +
+		"DELIMITER ;\n" +
+		"INSERT INTO users VALUES (1);"
+
+	reader := strings.NewReader(input)
+	p := NewParser(reader, SmallBufferSize)
+
+	stmt, err := p.ReadStatement()
+	if err != nil {
+		t.Fatalf("statement 0: unexpected error: %v", err)
+	}
+	wantTrigger := "CREATE TRIGGER before_insert_users BEFORE INSERT ON users FOR EACH ROW BEGIN SET NEW.created_at = NOW(); END$$"
+	if string(stmt) != wantTrigger {
+		t.Errorf("statement 0:\nwant: %q\ngot:  %q", wantTrigger, string(stmt))
+	}
+
+	stmt, err = p.ReadStatement()
+	if err != nil {
+		t.Fatalf("statement 1: unexpected error: %v", err)
+	}
+	wantInsert := "INSERT INTO users VALUES (1);"
+	if string(stmt) != wantInsert {
+		t.Errorf("statement 1:\nwant: %q\ngot:  %q", wantInsert, string(stmt))
+	}
+
+	if _, err := p.ReadStatement(); err != io.EOF {
+		t.Errorf("expected EOF, got: %v", err)
+	}
+}
+
+func TestParser_ReadStatement_PostgresDollarQuote(t *testing.T) {
+	input := "CREATE FUNCTION total(a int) RETURNS int AS $$ BEGIN RETURN a; END; $$ LANGUAGE plpgsql;\n" +
+		"INSERT INTO users VALUES (1);"
+
+	reader := strings.NewReader(input)
+	p := NewParser(reader, SmallBufferSize, WithDialect(Postgres))
+
+	stmt, err := p.ReadStatement()
+	if err != nil {
+		t.Fatalf("statement 0: unexpected error: %v", err)
+	}
+	wantFunc := "CREATE FUNCTION total(a int) RETURNS int AS $$ BEGIN RETURN a; END; $$ LANGUAGE plpgsql;"
+	if string(stmt) != wantFunc {
+		t.Errorf("statement 0:\nwant: %q\ngot:  %q", wantFunc, string(stmt))
+	}
+
+	stmt, err = p.ReadStatement()
+	if err != nil {
+		t.Fatalf("statement 1: unexpected error: %v", err)
+	}
+	wantInsert := "\nINSERT INTO users VALUES (1);"
+	if string(stmt) != wantInsert {
+		t.Errorf("statement 1:\nwant: %q\ngot:  %q", wantInsert, string(stmt))
+	}
+
+	if _, err := p.ReadStatement(); err != io.EOF {
+		t.Errorf("expected EOF, got: %v", err)
+	}
+}
+
+func TestParser_ReadStatement_PostgresTaggedDollarQuote(t *testing.T) {
+	input := "CREATE FUNCTION f() RETURNS int AS $body$ BEGIN RETURN 1; END; $body$ LANGUAGE plpgsql;"
+
+	p := NewParser(strings.NewReader(input), SmallBufferSize, WithDialect(Postgres))
+
+	stmt, err := p.ReadStatement()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(stmt) != input {
+		t.Errorf("want: %q\ngot:  %q", input, string(stmt))
+	}
+}
+
+func TestParser_ReadStatement_PostgresCopyFromStdin(t *testing.T) {
+	input := "COPY public.users (id, name) FROM stdin;\n" +
+		"1\tAlice\n" +
+		"2\tBob\n" +
+		`\.` + "\n" +
+		"INSERT INTO posts VALUES (1);"
+
+	p := NewParser(strings.NewReader(input), SmallBufferSize, WithDialect(Postgres))
+
+	stmt, err := p.ReadStatement()
+	if err != nil {
+		t.Fatalf("statement 0: unexpected error: %v", err)
+	}
+	wantCopy := "COPY public.users (id, name) FROM stdin;\n1\tAlice\n2\tBob\n" + `\.` + "\n"
+	if string(stmt) != wantCopy {
+		t.Errorf("statement 0:\nwant: %q\ngot:  %q", wantCopy, string(stmt))
+	}
+
+	stmtType, tableName := p.ParseStatement(stmt)
+	if stmtType != Copy {
+		t.Errorf("type: want Copy, got %v", stmtType)
+	}
+	if tableName != "public.users" {
+		t.Errorf("table: want %q, got %q", "public.users", tableName)
+	}
+
+	stmt, err = p.ReadStatement()
+	if err != nil {
+		t.Fatalf("statement 1: unexpected error: %v", err)
+	}
+	if string(stmt) != "INSERT INTO posts VALUES (1);" {
+		t.Errorf("statement 1: got %q", string(stmt))
+	}
+}
+
 func TestParser_ParseStatement(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -125,6 +236,114 @@ func TestParser_ParseStatement(t *testing.T) {
 			expectedType:  Unknown,
 			expectedTable: "",
 		},
+		{
+			name:          "begin",
+			input:         "BEGIN;",
+			expectedType:  Begin,
+			expectedTable: "",
+		},
+		{
+			name:          "start transaction",
+			input:         "START TRANSACTION;",
+			expectedType:  Begin,
+			expectedTable: "",
+		},
+		{
+			name:          "commit",
+			input:         "COMMIT;",
+			expectedType:  Commit,
+			expectedTable: "",
+		},
+		{
+			name:          "rollback",
+			input:         "ROLLBACK;",
+			expectedType:  Rollback,
+			expectedTable: "",
+		},
+		{
+			name:          "use database",
+			input:         "USE `mydb`;",
+			expectedType:  Use,
+			expectedTable: "mydb",
+		},
+		{
+			name:          "lock tables",
+			input:         "LOCK TABLES `users` WRITE;",
+			expectedType:  LockTables,
+			expectedTable: "users",
+		},
+		{
+			name:          "unlock tables",
+			input:         "UNLOCK TABLES;",
+			expectedType:  UnlockTables,
+			expectedTable: "",
+		},
+		{
+			name:          "set session variable",
+			input:         "SET @@session.sql_mode = '';",
+			expectedType:  Set,
+			expectedTable: "",
+		},
+		{
+			name:          "line comment",
+			input:         "-- dumping data for table users",
+			expectedType:  Comment,
+			expectedTable: "",
+		},
+		{
+			name:          "create trigger",
+			input:         "CREATE TRIGGER before_insert_users BEFORE INSERT ON users FOR EACH ROW BEGIN SET NEW.created_at = NOW(); END$$",
+			expectedType:  CreateTrigger,
+			expectedTable: "before_insert_users",
+		},
+		{
+			name:          "create trigger with definer",
+			input:         "CREATE DEFINER=`root`@`localhost` TRIGGER `before_insert_users` BEFORE INSERT ON `users` FOR EACH ROW BEGIN END$$",
+			expectedType:  CreateTrigger,
+			expectedTable: "before_insert_users",
+		},
+		{
+			name:          "create function with definer",
+			input:         "CREATE DEFINER=`root`@`localhost` FUNCTION `total_orders`(uid INT) RETURNS INT BEGIN RETURN 0; END$$",
+			expectedType:  CreateFunction,
+			expectedTable: "total_orders",
+		},
+		{
+			name:          "create procedure with definer",
+			input:         "CREATE DEFINER=`root`@`localhost` PROCEDURE `archive_orders`() BEGIN END$$",
+			expectedType:  CreateProcedure,
+			expectedTable: "archive_orders",
+		},
+		{
+			name:          "create view",
+			input:         "CREATE OR REPLACE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `active_users` AS SELECT * FROM users WHERE active = 1;",
+			expectedType:  CreateView,
+			expectedTable: "active_users",
+		},
+		{
+			name:          "session setup SET wrapped in a conditional-execution comment",
+			input:         "/*!40101 SET NAMES utf8mb4 */;",
+			expectedType:  SessionSetup,
+			expectedTable: "",
+		},
+		{
+			name:          "session setup SET with no leading whitespace before the bang",
+			input:         "/*!40103 SET TIME_ZONE='+00:00' */;",
+			expectedType:  SessionSetup,
+			expectedTable: "",
+		},
+		{
+			name:          "create table with an executable comment before the table name",
+			input:         "CREATE /*!32312 IF NOT EXISTS*/ TABLE `users` (id INT);",
+			expectedType:  CreateTable,
+			expectedTable: "users",
+		},
+		{
+			name:          "insert into with a plain block comment",
+			input:         "INSERT /* bulk load */ INTO users VALUES (1, 'test');",
+			expectedType:  Insert,
+			expectedTable: "users",
+		},
 	}
 
 	for _, tt := range tests {
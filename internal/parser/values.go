@@ -0,0 +1,377 @@
+package parser
+
+import "bytes"
+
+// findValuesKeyword returns the index range of the VALUES keyword
+// introducing an INSERT statement's row list - end is the first
+// non-whitespace byte after it - so NewValuesIterator and
+// ExtractInsertColumns can split the statement into its head (table/column
+// list) and tail (tuples). Unlike a plain regex match, it tracks
+// quote/backtick state so a column literally named `values` isn't mistaken
+// for the keyword. Returns ok=false if no standalone VALUES is found.
+func findValuesKeyword(stmt []byte) (start, end int, ok bool) {
+	insideSingle, insideDouble, insideBacktick := false, false, false
+	escaped := false
+
+	for i := 0; i < len(stmt); i++ {
+		b := stmt[i]
+
+		if insideSingle || insideDouble {
+			if (b == '\'' && insideSingle || b == '"' && insideDouble) && !escaped {
+				insideSingle, insideDouble = false, false
+			}
+			if b == '\\' && !escaped {
+				escaped = true
+			} else {
+				escaped = false
+			}
+			continue
+		}
+		if insideBacktick {
+			if b == '`' {
+				insideBacktick = false
+			}
+			continue
+		}
+
+		switch b {
+		case '\'':
+			insideSingle = true
+			continue
+		case '"':
+			insideDouble = true
+			continue
+		case '`':
+			insideBacktick = true
+			continue
+		}
+
+		if i+6 <= len(stmt) && bytes.EqualFold(stmt[i:i+6], []byte("VALUES")) {
+			beforeOK := i == 0 || !isIdentByte(stmt[i-1])
+			afterIdx := i + 6
+			afterOK := afterIdx >= len(stmt) || !isIdentByte(stmt[afterIdx])
+			if beforeOK && afterOK {
+				j := afterIdx
+				for j < len(stmt) && isWhitespace(stmt[j]) {
+					j++
+				}
+				return i, j, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// isIdentByte reports whether b can appear in an unquoted SQL identifier or
+// keyword, used by findValuesKeyword to check VALUES is a standalone word.
+func isIdentByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// ValuesIterator walks the "VALUES (...), (...), ..." tail of an INSERT
+// statement (as classified by ParseStatement's Insert type), yielding each
+// row's raw column values one at a time. Returned slices alias stmt, so no
+// row is ever copied.
+type ValuesIterator struct {
+	data []byte
+	pos  int
+}
+
+// NewValuesIterator returns an iterator over stmt's VALUES tuples, starting
+// just past the "VALUES" keyword. If stmt has no VALUES keyword, the
+// returned iterator yields no rows.
+func NewValuesIterator(stmt []byte) *ValuesIterator {
+	v := &ValuesIterator{data: stmt, pos: len(stmt)}
+	if _, end, ok := findValuesKeyword(stmt); ok {
+		v.pos = end
+	}
+	return v
+}
+
+// Next returns the next row's raw column values - each still in its
+// original SQL literal form (quoted strings keep their quotes and escaping)
+// - or ok=false once the tuples are exhausted.
+func (v *ValuesIterator) Next() (row [][]byte, ok bool) {
+	for v.pos < len(v.data) && (isWhitespace(v.data[v.pos]) || v.data[v.pos] == ',') {
+		v.pos++
+	}
+	if v.pos >= len(v.data) || v.data[v.pos] != '(' {
+		return nil, false
+	}
+	v.pos++ // consume '('
+
+	for {
+		for v.pos < len(v.data) && isWhitespace(v.data[v.pos]) {
+			v.pos++
+		}
+		if v.pos >= len(v.data) {
+			return row, true
+		}
+		if v.data[v.pos] == ')' {
+			v.pos++
+			return row, true
+		}
+
+		val, next := v.readValue()
+		row = append(row, val)
+		v.pos = next
+
+		for v.pos < len(v.data) && isWhitespace(v.data[v.pos]) {
+			v.pos++
+		}
+		if v.pos < len(v.data) && v.data[v.pos] == ',' {
+			v.pos++
+		}
+	}
+}
+
+// readValue reads a single tuple element starting at v.pos: a single- or
+// double-quoted string - honoring the same backslash-escape rule
+// readStatementSingleByte uses to find a statement's terminator - or an
+// unquoted literal (a number, NULL, or other bare token) read up to the
+// next top-level ',' or ')'.
+func (v *ValuesIterator) readValue() (val []byte, next int) {
+	start := v.pos
+
+	if v.data[v.pos] == '\'' || v.data[v.pos] == '"' {
+		quote := v.data[v.pos]
+		i := v.pos + 1
+		escaped := false
+		for i < len(v.data) {
+			c := v.data[i]
+			if c == quote && !escaped {
+				i++
+				break
+			}
+			if c == '\\' && !escaped {
+				escaped = true
+			} else {
+				escaped = false
+			}
+			i++
+		}
+		return v.data[start:i], i
+	}
+
+	i := v.pos
+	for i < len(v.data) && v.data[i] != ',' && v.data[i] != ')' {
+		i++
+	}
+	end := i
+	for end > start && isWhitespace(v.data[end-1]) {
+		end--
+	}
+	return v.data[start:end], i
+}
+
+// ExtractColumnNames parses a CREATE TABLE statement's column definitions
+// and returns their names in declaration order, skipping constraint clauses
+// (PRIMARY KEY, UNIQUE KEY, KEY, INDEX, CONSTRAINT, FOREIGN KEY, CHECK,
+// FULLTEXT, SPATIAL). Returns nil if stmt has no parseable column list.
+func ExtractColumnNames(stmt []byte) []string {
+	open := bytes.IndexByte(stmt, '(')
+	if open == -1 {
+		return nil
+	}
+	body, ok := matchingParen(stmt, open)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, def := range splitTopLevel(body) {
+		def = bytes.TrimSpace(def)
+		if len(def) == 0 || isConstraintClause(def) {
+			continue
+		}
+		if name := firstIdentifier(def); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ExtractInsertColumns returns the optional column list from
+// "INSERT INTO t (a, b, c) VALUES ...", or nil if the statement omits it,
+// as in the common "INSERT INTO t VALUES ..." dump form.
+func ExtractInsertColumns(stmt []byte) []string {
+	start, _, ok := findValuesKeyword(stmt)
+	if !ok {
+		return nil
+	}
+	head := stmt[:start]
+
+	open := bytes.IndexByte(head, '(')
+	if open == -1 {
+		return nil
+	}
+	body, ok := matchingParen(head, open)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, part := range splitTopLevel(body) {
+		if name := firstIdentifier(bytes.TrimSpace(part)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// constraintKeywords are the clause prefixes ExtractColumnNames skips
+// instead of treating as a column definition.
+var constraintKeywords = [][]byte{
+	[]byte("PRIMARY KEY"),
+	[]byte("UNIQUE KEY"),
+	[]byte("UNIQUE"),
+	[]byte("KEY"),
+	[]byte("INDEX"),
+	[]byte("CONSTRAINT"),
+	[]byte("FOREIGN KEY"),
+	[]byte("CHECK"),
+	[]byte("FULLTEXT"),
+	[]byte("SPATIAL"),
+}
+
+func isConstraintClause(def []byte) bool {
+	upper := bytes.ToUpper(def)
+	for _, kw := range constraintKeywords {
+		if bytes.HasPrefix(upper, kw) && (len(upper) == len(kw) || !isIdentByte(upper[len(kw)])) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstIdentifier returns def's leading identifier - a backtick/double-quoted
+// name, or a bare token up to the next whitespace or '(' - with any quotes
+// stripped. Returns "" if def starts with neither.
+func firstIdentifier(def []byte) string {
+	i := 0
+	for i < len(def) && isWhitespace(def[i]) {
+		i++
+	}
+	if i >= len(def) {
+		return ""
+	}
+
+	if def[i] == '`' || def[i] == '"' {
+		quote := def[i]
+		i++
+		start := i
+		for i < len(def) && def[i] != quote {
+			i++
+		}
+		if i >= len(def) {
+			return ""
+		}
+		return string(def[start:i])
+	}
+
+	start := i
+	for i < len(def) && !isWhitespace(def[i]) && def[i] != '(' {
+		i++
+	}
+	return string(def[start:i])
+}
+
+// matchingParen returns the bytes strictly between stmt[openIdx] (which
+// must be '(') and its matching ')', honoring nested parens and quoted
+// strings (so a column type like "VARCHAR(255)" or a default value like
+// "')'" doesn't confuse the scan). Returns ok=false if no match is found.
+func matchingParen(stmt []byte, openIdx int) (body []byte, ok bool) {
+	depth := 0
+	insideSingle, insideDouble, insideBacktick := false, false, false
+	escaped := false
+
+	for i := openIdx; i < len(stmt); i++ {
+		b := stmt[i]
+
+		if insideSingle || insideDouble {
+			if (b == '\'' && insideSingle || b == '"' && insideDouble) && !escaped {
+				insideSingle, insideDouble = false, false
+			}
+			if b == '\\' && !escaped {
+				escaped = true
+			} else {
+				escaped = false
+			}
+			continue
+		}
+		if insideBacktick {
+			if b == '`' {
+				insideBacktick = false
+			}
+			continue
+		}
+
+		switch {
+		case b == '\'':
+			insideSingle = true
+		case b == '"':
+			insideDouble = true
+		case b == '`':
+			insideBacktick = true
+		case b == '(':
+			depth++
+		case b == ')':
+			depth--
+			if depth == 0 {
+				return stmt[openIdx+1 : i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// splitTopLevel splits body on commas that appear outside any nested
+// parens or quoted string, so a column definition like
+// "price DECIMAL(10,2) DEFAULT 0" stays one part.
+func splitTopLevel(body []byte) [][]byte {
+	var parts [][]byte
+	depth := 0
+	insideSingle, insideDouble, insideBacktick := false, false, false
+	escaped := false
+	start := 0
+
+	for i := 0; i < len(body); i++ {
+		b := body[i]
+
+		if insideSingle || insideDouble {
+			if (b == '\'' && insideSingle || b == '"' && insideDouble) && !escaped {
+				insideSingle, insideDouble = false, false
+			}
+			if b == '\\' && !escaped {
+				escaped = true
+			} else {
+				escaped = false
+			}
+			continue
+		}
+		if insideBacktick {
+			if b == '`' {
+				insideBacktick = false
+			}
+			continue
+		}
+
+		switch {
+		case b == '\'':
+			insideSingle = true
+		case b == '"':
+			insideDouble = true
+		case b == '`':
+			insideBacktick = true
+		case b == '(':
+			depth++
+		case b == ')':
+			depth--
+		case b == ',' && depth == 0:
+			parts = append(parts, body[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, body[start:])
+	return parts
+}
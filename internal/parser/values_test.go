@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValuesIterator_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected [][]string
+	}{
+		{
+			name:     "single row of numbers and NULL",
+			input:    "INSERT INTO users VALUES (1, 2, NULL);",
+			expected: [][]string{{"1", "2", "NULL"}},
+		},
+		{
+			name:     "multiple rows",
+			input:    "INSERT INTO users VALUES (1, 'alice'), (2, 'bob');",
+			expected: [][]string{{"1", "'alice'"}, {"2", "'bob'"}},
+		},
+		{
+			name:     "string containing a comma and a closing paren",
+			input:    "INSERT INTO users VALUES (1, 'hello, world)');",
+			expected: [][]string{{"1", "'hello, world)'"}},
+		},
+		{
+			name:     "escaped quote inside a string",
+			input:    "INSERT INTO users VALUES (1, 'it\\'s working');",
+			expected: [][]string{{"1", "'it\\'s working'"}},
+		},
+		{
+			name:     "double-quoted string",
+			input:    `INSERT INTO users VALUES (1, "hello");`,
+			expected: [][]string{{"1", `"hello"`}},
+		},
+		{
+			name:     "column list before VALUES doesn't confuse row scanning",
+			input:    "INSERT INTO users (id, name) VALUES (1, 'alice');",
+			expected: [][]string{{"1", "'alice'"}},
+		},
+		{
+			name:     "no VALUES keyword yields no rows",
+			input:    "CREATE TABLE users (id INT);",
+			expected: nil,
+		},
+		{
+			name:     "column literally named values doesn't confuse the keyword split",
+			input:    "INSERT INTO t (`id`, `values`) VALUES (1, 2);",
+			expected: [][]string{{"1", "2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := NewValuesIterator([]byte(tt.input))
+
+			var got [][]string
+			for {
+				row, ok := it.Next()
+				if !ok {
+					break
+				}
+				strs := make([]string, len(row))
+				for i, v := range row {
+					strs[i] = string(v)
+				}
+				got = append(got, strs)
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("want %#v, got %#v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExtractColumnNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "simple columns",
+			input:    "CREATE TABLE users (id INT, name VARCHAR(255));",
+			expected: []string{"id", "name"},
+		},
+		{
+			name:     "backtick-quoted columns",
+			input:    "CREATE TABLE `users` (`id` INT, `name` VARCHAR(255));",
+			expected: []string{"id", "name"},
+		},
+		{
+			name:     "skips PRIMARY KEY and KEY clauses",
+			input:    "CREATE TABLE users (id INT, name VARCHAR(255), PRIMARY KEY (id), KEY idx_name (name));",
+			expected: []string{"id", "name"},
+		},
+		{
+			name:     "column type with a comma doesn't split the column",
+			input:    "CREATE TABLE users (id INT, price DECIMAL(10,2));",
+			expected: []string{"id", "price"},
+		},
+		{
+			name:     "no parens returns nil",
+			input:    "DROP TABLE users;",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractColumnNames([]byte(tt.input))
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("want %#v, got %#v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExtractInsertColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "explicit column list",
+			input:    "INSERT INTO users (id, name) VALUES (1, 'alice');",
+			expected: []string{"id", "name"},
+		},
+		{
+			name:     "backtick-quoted column list",
+			input:    "INSERT INTO `users` (`id`, `name`) VALUES (1, 'alice');",
+			expected: []string{"id", "name"},
+		},
+		{
+			name:     "no column list returns nil",
+			input:    "INSERT INTO users VALUES (1, 'alice');",
+			expected: nil,
+		},
+		{
+			name:     "column literally named values doesn't confuse the keyword split",
+			input:    "INSERT INTO t (`id`, `values`) VALUES (1, 2);",
+			expected: []string{"id", "values"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractInsertColumns([]byte(tt.input))
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("want %#v, got %#v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package splitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// Checkpoint captures enough state to resume an interrupted Split: the byte
+// offset into the input, per-table statement counts, and which table files
+// have been finalized so far (and must therefore be reopened for append,
+// not truncated, on resume). InputSize and InputModTime are recorded so a
+// resume can detect that the input file changed since the checkpoint was
+// written and refuse to continue against stale data. It is only ever
+// written between complete statements (see splitSequential), so Offset
+// never lands mid-statement. ChunkIndex, ChunkBytes, and CreateStmts mirror
+// Splitter's own chunkIndex/chunkBytes/createStmts bookkeeping, so a resume
+// combining WithMaxFileSize with WithCheckpoint picks chunk numbering,
+// rotation accounting, and chunk-header replay back up where they left off
+// instead of restarting every table at chunk 1; they're omitted entirely
+// when WithMaxFileSize isn't set.
+type Checkpoint struct {
+	Offset              int64             `json:"offset"`
+	TableStmtCounts     map[string]int64  `json:"table_stmt_counts"`
+	FinalizedTables     []string          `json:"finalized_tables,omitempty"`
+	StatementsProcessed int64             `json:"statements_processed"`
+	BytesProcessed      int64             `json:"bytes_processed"`
+	InputSize           int64             `json:"input_size"`
+	InputModTime        time.Time         `json:"input_mod_time"`
+	ChunkIndex          map[string]int    `json:"chunk_index,omitempty"`
+	ChunkBytes          map[string]int64  `json:"chunk_bytes,omitempty"`
+	CreateStmts         map[string][]byte `json:"create_stmts,omitempty"`
+}
+
+// WriteCheckpoint writes cp to path on fs, replacing any existing file
+// atomically so a crash mid-write never leaves a corrupt checkpoint.
+func WriteCheckpoint(fs afero.Fs, path string, cp Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := afero.WriteFile(fs, tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := fs.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to finalize checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// LoadCheckpoint reads a checkpoint previously written by WriteCheckpoint.
+func LoadCheckpoint(fs afero.Fs, path string) (*Checkpoint, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+
+	return &cp, nil
+}
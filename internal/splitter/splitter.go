@@ -1,30 +1,73 @@
 package splitter
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/helgesverre/sql-splitter/internal/compress"
 	"github.com/helgesverre/sql-splitter/internal/parser"
 	"github.com/helgesverre/sql-splitter/internal/writer"
+	"github.com/spf13/afero"
 )
 
 // Splitter handles the SQL file splitting with concurrent processing
 type Splitter struct {
-	inputFile    string
-	outputDir    string
-	writerPool   *writer.WriterPool
-	stats        *Stats
-	mu           sync.Mutex
-	tableFilter  map[string]bool // If set, only split these tables
-	dryRun       bool            // If true, don't write files
-	progressFn   func(bytesRead int64)
-	tablesSeen   map[string]bool // Track unique tables for dry-run
-}
-
-// Stats tracks processing statistics
+	fs          afero.Fs
+	inputFile   string
+	outputDir   string
+	writerPool  *writer.WriterPool
+	stats       *Stats
+	mu          sync.Mutex
+	tableFilter map[string]bool // If set, only split these tables
+	dryRun      bool            // If true, don't write files
+	progressFn  func(bytesRead int64)
+	tablesSeen  map[string]bool // Track unique tables for dry-run
+
+	sessionPreamble   bool
+	sessionSetStmts   [][]byte // SET statements seen so far, replayed into each new table file
+	sessionSetupStmts [][]byte // mysqldump's conditional-execution-commented session SET statements (parser.SessionSetup), written once to a shared _preamble.sql
+
+	compression         compress.Algorithm // Input decompression algorithm; compress.Auto sniffs it
+	outputCompression   compress.Algorithm // Output compression algorithm applied to each table file; compress.None disables it
+	outputCompressLevel int                // Passed through to compress.WrapWriter; 0 means the algorithm's default
+
+	concurrency int // Number of worker goroutines writing statements; see WithConcurrency
+
+	checkpointPath     string // See WithCheckpoint; non-empty enables periodic checkpointing
+	checkpointInterval int
+	resumeRequested    bool // See WithResume
+
+	tableStmtCounts map[string]int64 // Per-table statement counts, recorded into each checkpoint
+	inputSize       int64            // Input file size as of this Split(), recorded into each checkpoint
+	inputModTime    time.Time        // Input file mtime as of this Split(), recorded into each checkpoint
+
+	maxFileSize int64 // See WithMaxFileSize; <= 0 disables chunking
+
+	dialect parser.Dialect // See WithDialect; parser.MySQL by default
+
+	outputFormat  OutputFormat        // See WithOutputFormat; FormatSQL by default
+	tableColumns  map[string][]string // Each table's CREATE TABLE column names, snapshotted into Insert work items when outputFormat != FormatSQL
+	tableDataSeen map[string]bool     // Tracks whether a table's first Insert has been dispatched yet, so its CSV header is written exactly once
+
+	chunkMu     sync.Mutex        // Guards createStmts/chunkIndex/chunkBytes below
+	createStmts map[string][]byte // Each table's CREATE TABLE statement, replayed into rotated chunks
+	chunkIndex  map[string]int    // Current 1-based chunk number per table; 0 means chunk 1
+	chunkBytes  map[string]int64  // Bytes written to the current chunk so far
+}
+
+// Stats tracks processing statistics. StatementsProcessed and BytesProcessed
+// are updated via atomic.AddInt64 from the reader goroutine during a
+// concurrent Split, so read them with atomic.LoadInt64 if you read *Stats
+// directly while a Split is running. GetStats copies the struct under s.mu
+// without atomic loads; its result is exact once Split has returned.
 type Stats struct {
 	StatementsProcessed int64
 	TablesFound         int
@@ -61,19 +104,152 @@ func WithProgress(fn func(bytesRead int64)) Option {
 	}
 }
 
+// WithConcurrency sets the number of worker goroutines that write statements
+// to their table's output file. Statements are sharded across workers by
+// hash of table name, so every table is always handled by exactly one
+// worker and its statements are written in source order; only I/O and
+// compression across different tables run in parallel. n < 1 is treated as
+// 1 (sequential).
+func WithConcurrency(n int) Option {
+	return func(s *Splitter) {
+		s.concurrency = n
+	}
+}
+
+// WithSessionPreamble makes each per-table output file independently
+// replayable: every SET statement seen so far is re-emitted at the top of a
+// table's file the first time that table is written, followed by a
+// synthesized LOCK TABLES line, and UNLOCK TABLES; is appended once the
+// whole input has been processed. This lets a single table file be restored
+// on its own (mysql < table.sql) instead of requiring the full dump.
+func WithSessionPreamble(enabled bool) Option {
+	return func(s *Splitter) {
+		s.sessionPreamble = enabled
+	}
+}
+
+// WithFS sets the filesystem backing the input read and all output writes.
+// Defaults to afero.NewOsFs(); pass afero.NewMemMapFs() for tests, or a
+// remote-object-store afero.Fs to split a dump straight from/to a bucket
+// without ever staging it on local disk.
+func WithFS(fs afero.Fs) Option {
+	return func(s *Splitter) {
+		s.fs = fs
+	}
+}
+
+// WithCompression sets the input decompression algorithm. Defaults to
+// compress.Auto, which sniffs magic bytes and falls back to the file
+// extension to detect gzip/zstd/snappy/bzip2/xz input automatically.
+func WithCompression(algo compress.Algorithm) Option {
+	return func(s *Splitter) {
+		s.compression = algo
+	}
+}
+
+// WithOutputCompression transparently compresses each per-table output file
+// with algo at the given level (algorithm-specific; 0 means the algorithm's
+// default), appending the algorithm's conventional extension (e.g. ".gz") to
+// the filename. Only compress.Gzip, compress.Zstd, and compress.None are
+// supported as output codecs.
+func WithOutputCompression(algo compress.Algorithm, level int) Option {
+	return func(s *Splitter) {
+		s.outputCompression = algo
+		s.outputCompressLevel = level
+	}
+}
+
+// WithMaxFileSize caps each table's output file at approximately maxBytes:
+// once writing the next statement would cross the limit, Split closes the
+// current file and rotates to a new one named "<table>.NNNN.sql" (NNNN is a
+// zero-padded, 1-based chunk number), re-emitting the table's CREATE TABLE
+// statement - and, if WithSessionPreamble is set, the session preamble - so
+// each chunk can be replayed on its own. maxBytes <= 0 disables chunking
+// (the default): a single "<table>.sql" file per table.
+func WithMaxFileSize(maxBytes int64) Option {
+	return func(s *Splitter) {
+		s.maxFileSize = maxBytes
+	}
+}
+
+// WithDialect sets the SQL dialect used to parse the input. Defaults to
+// parser.MySQL; pass parser.Postgres to split pg_dump output.
+func WithDialect(d parser.Dialect) Option {
+	return func(s *Splitter) {
+		s.dialect = d
+	}
+}
+
+// WithOutputFormat selects how Insert rows are written to each table's
+// output file. Defaults to FormatSQL (each statement's raw bytes,
+// unchanged). FormatCSV and FormatJSONL instead transform each Insert's
+// VALUES tuples into rows of "<table>.csv"/"<table>.jsonl", routing
+// everything else (CREATE TABLE, CREATE INDEX, ALTER TABLE, ...) to a
+// companion "<table>.schema.sql" so the original DDL stays available.
+// WithMaxFileSize and WithSessionPreamble only apply to FormatSQL.
+func WithOutputFormat(format OutputFormat) Option {
+	return func(s *Splitter) {
+		s.outputFormat = format
+	}
+}
+
+// WithCheckpoint makes Split periodically write a resumable checkpoint to
+// path every interval statements (default 1000 when <= 0). Enabling a
+// checkpoint forces Split to process the file on a single goroutine
+// (WithConcurrency is ignored): a checkpoint is only ever written once
+// every table writer has been flushed, which the concurrent pipeline can't
+// guarantee without serializing it anyway.
+func WithCheckpoint(path string, interval int) Option {
+	if interval <= 0 {
+		interval = 1000
+	}
+	return func(s *Splitter) {
+		s.checkpointPath = path
+		s.checkpointInterval = interval
+	}
+}
+
+// WithResume makes Split look for an existing checkpoint at the path given
+// to WithCheckpoint. If one exists and its recorded input size/mtime match
+// the input file, Split seeks to the checkpointed offset, reopens each
+// table's output file in append mode, and continues; otherwise it returns
+// an error naming the checkpoint as stale. If no checkpoint file exists
+// yet, Split just starts from the beginning. Has no effect without
+// WithCheckpoint.
+func WithResume(enabled bool) Option {
+	return func(s *Splitter) {
+		s.resumeRequested = enabled
+	}
+}
+
 // NewSplitter creates a new SQL file splitter
 func NewSplitter(inputFile, outputDir string, opts ...Option) *Splitter {
 	s := &Splitter{
-		inputFile:  inputFile,
-		outputDir:  outputDir,
-		writerPool: writer.NewWriterPool(),
-		stats:      &Stats{},
-		tablesSeen: make(map[string]bool),
+		fs:              afero.NewOsFs(),
+		inputFile:       inputFile,
+		outputDir:       outputDir,
+		stats:           &Stats{},
+		tablesSeen:      make(map[string]bool),
+		compression:     compress.Auto,
+		concurrency:     1,
+		tableStmtCounts: make(map[string]int64),
+		createStmts:     make(map[string][]byte),
+		chunkIndex:      make(map[string]int),
+		chunkBytes:      make(map[string]int64),
+		dialect:         parser.MySQL,
+		outputFormat:    FormatSQL,
+		tableColumns:    make(map[string][]string),
+		tableDataSeen:   make(map[string]bool),
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
+	if s.concurrency < 1 {
+		s.concurrency = 1
+	}
+
+	s.writerPool = writer.NewWriterPool(s.fs, s.outputCompression, s.outputCompressLevel)
 
 	return s
 }
@@ -82,13 +258,13 @@ func NewSplitter(inputFile, outputDir string, opts ...Option) *Splitter {
 func (s *Splitter) Split() error {
 	// Create output directory if it doesn't exist (skip for dry-run)
 	if !s.dryRun {
-		if err := os.MkdirAll(s.outputDir, 0755); err != nil {
+		if err := s.fs.MkdirAll(s.outputDir, 0755); err != nil {
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 	}
 
 	// Open input file
-	file, err := os.Open(s.inputFile)
+	file, err := s.fs.Open(s.inputFile)
 	if err != nil {
 		return fmt.Errorf("failed to open input file: %w", err)
 	}
@@ -99,6 +275,18 @@ func (s *Splitter) Split() error {
 	if err != nil {
 		return fmt.Errorf("failed to stat input file: %w", err)
 	}
+	s.inputSize = fileInfo.Size()
+	s.inputModTime = fileInfo.ModTime()
+
+	// If resuming, load the checkpoint (if any) and seek past the
+	// already-processed prefix before wrapping the file in anything else
+	var startOffset int64
+	if s.checkpointPath != "" && s.resumeRequested {
+		startOffset, err = s.loadResumeState(file, fileInfo)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Wrap file with progress reader if callback is set
 	var reader io.Reader = file
@@ -106,14 +294,485 @@ func (s *Splitter) Split() error {
 		reader = &progressReader{
 			reader:   file,
 			callback: s.progressFn,
+			read:     startOffset,
 		}
 	}
 
+	// Transparently decompress the input if needed
+	reader, err = compress.Wrap(reader, s.compression, s.inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to decompress input file: %w", err)
+	}
+
 	// Create parser with optimal buffer size
 	bufferSize := parser.DetermineBufferSize(fileInfo.Size())
-	p := parser.NewParser(reader, bufferSize)
+	p := parser.NewParser(reader, bufferSize, parser.WithDialect(s.dialect))
+
+	// A checkpoint requires every statement up to its offset to already be
+	// flushed to disk, which the concurrent pipeline below can't promise
+	// without serializing it anyway - so checkpointed runs take the simpler
+	// single-goroutine path instead.
+	if s.checkpointPath != "" {
+		return s.splitSequential(p, startOffset)
+	}
+
+	// Producer: read and classify statements sequentially (table filtering,
+	// first-seen tracking, and SET-preamble accumulation all depend on
+	// source order), then fan them out to a bounded channel per worker.
+	// Sharding by hash of tableName guarantees every table is always
+	// handled by the same worker, so its statements land in its file in
+	// source order even though different tables write concurrently.
+	shardChans := make([]chan workItem, s.concurrency)
+	for i := range shardChans {
+		shardChans[i] = make(chan workItem, 64)
+	}
+
+	var readErr error
+	var readWg sync.WaitGroup
+	readWg.Add(1)
+	go func() {
+		defer readWg.Done()
+		defer func() {
+			for _, ch := range shardChans {
+				close(ch)
+			}
+		}()
+
+		for {
+			stmt, err := p.ReadStatement()
+			if err != nil {
+				if err != io.EOF {
+					readErr = fmt.Errorf("error reading statement: %w", err)
+				}
+				return
+			}
+
+			// Parse statement type and table name
+			stmtType, tableName := p.ParseStatement(stmt)
+
+			// Accumulate SET statements so they can be replayed into each
+			// table's own file, regardless of table filtering below
+			if s.sessionPreamble && stmtType == parser.Set {
+				s.sessionSetStmts = append(s.sessionSetStmts, append([]byte(nil), stmt...))
+			}
+
+			// mysqldump's conditional-execution-commented session SET
+			// statements are collected regardless of --session-preamble, so
+			// they can be written once to a shared _preamble.sql instead of
+			// being duplicated into every table file
+			if stmtType == parser.SessionSetup {
+				s.sessionSetupStmts = append(s.sessionSetupStmts, append([]byte(nil), stmt...))
+			}
+
+			// Capture each table's CREATE TABLE so it can be replayed into a
+			// rotated chunk, which won't otherwise contain it
+			if s.maxFileSize > 0 && stmtType == parser.CreateTable {
+				s.chunkMu.Lock()
+				s.createStmts[tableName] = append([]byte(nil), stmt...)
+				s.chunkMu.Unlock()
+			}
+
+			// Remember each table's columns so they can be attached to its
+			// Insert work items below; only the producer goroutine ever
+			// touches this map, so no lock is needed
+			if s.outputFormat != FormatSQL && stmtType == parser.CreateTable {
+				s.tableColumns[tableName] = parser.ExtractColumnNames(stmt)
+			}
+
+			// Skip unknown statements or those without table names
+			if stmtType == parser.Unknown || tableName == "" {
+				continue
+			}
+
+			// Apply table filter if set
+			if s.tableFilter != nil && !s.tableFilter[tableName] {
+				continue
+			}
+
+			// Track unique tables
+			firstSeen := !s.tablesSeen[tableName]
+			if firstSeen {
+				s.tablesSeen[tableName] = true
+				s.mu.Lock()
+				s.stats.TablesFound++
+				s.stats.TableNames = append(s.stats.TableNames, tableName)
+				s.mu.Unlock()
+			}
+
+			atomic.AddInt64(&s.stats.StatementsProcessed, 1)
+			atomic.AddInt64(&s.stats.BytesProcessed, int64(len(stmt)))
+
+			// In dry-run mode, nothing is written, so there's no work to
+			// hand off to a writer goroutine
+			if s.dryRun {
+				continue
+			}
+
+			item := workItem{tableName: tableName, stmt: stmt, stmtType: stmtType, firstSeen: firstSeen}
+			// A rotation can happen on any statement once chunking is
+			// active, not just the table's first, so the preamble has to be
+			// snapshotted here regardless of firstSeen - the worker that
+			// ends up writing it can't safely read s.sessionSetStmts itself
+			// while the producer keeps appending to it.
+			if s.sessionPreamble && (firstSeen || s.maxFileSize > 0) {
+				item.preamble = append([][]byte(nil), s.sessionSetStmts...)
+			}
+			if s.outputFormat != FormatSQL {
+				item.columns = s.tableColumns[tableName]
+				if stmtType == parser.Insert && !s.tableDataSeen[tableName] {
+					s.tableDataSeen[tableName] = true
+					item.firstDataRow = true
+				}
+			}
+			shardChans[s.shardFor(tableName)] <- item
+		}
+	}()
+
+	var writeErr atomic.Value // stores the first error, if any, from any worker
+	var workerWg sync.WaitGroup
+	for i := 0; i < s.concurrency; i++ {
+		workerWg.Add(1)
+		go func(ch chan workItem) {
+			defer workerWg.Done()
+			for item := range ch {
+				if err := s.writeItem(item); err != nil {
+					writeErr.CompareAndSwap(nil, err)
+				}
+			}
+		}(shardChans[i])
+	}
+
+	readWg.Wait()
+	workerWg.Wait()
+
+	if readErr != nil {
+		return readErr
+	}
+	if err, ok := writeErr.Load().(error); ok {
+		return err
+	}
+
+	return s.finishWriting()
+}
+
+// finishWriting appends the UNLOCK TABLES; footer (when WithSessionPreamble
+// is set) and closes every writer in the pool. Shared by the concurrent
+// Split pipeline and splitSequential.
+func (s *Splitter) finishWriting() error {
+	// Append UNLOCK TABLES; to every table file so it stands on its own.
+	// Session preamble only applies to FormatSQL - in CSV/JSONL mode the
+	// writer cached under tableName is the data file, not a .sql file, and
+	// an UNLOCK TABLES; line has no place in a CSV/JSONL row stream.
+	if !s.dryRun && s.sessionPreamble && s.outputFormat == FormatSQL {
+		for tableName := range s.tablesSeen {
+			filename := s.outputFilename(tableName)
+			w, err := s.writerPool.GetWriter(tableName, filename)
+			if err != nil {
+				return fmt.Errorf("failed to get writer for table %s: %w", tableName, err)
+			}
+			if err := w.WriteStatement([]byte("UNLOCK TABLES;")); err != nil {
+				return fmt.Errorf("failed to write unlock footer for table %s: %w", tableName, err)
+			}
+		}
+	}
+
+	// Write any mysqldump session-setup SET statements to a single shared
+	// preamble file that every per-table file can be restored alongside,
+	// instead of duplicating them into each one
+	if !s.dryRun && len(s.sessionSetupStmts) > 0 {
+		filename := filepath.Join(s.outputDir, "_preamble.sql"+compress.Extension(s.outputCompression))
+		w, err := s.writerPool.GetWriter("_preamble", filename)
+		if err != nil {
+			return fmt.Errorf("failed to get writer for preamble: %w", err)
+		}
+		for _, stmt := range s.sessionSetupStmts {
+			if err := w.WriteStatement(stmt); err != nil {
+				return fmt.Errorf("failed to write preamble statement: %w", err)
+			}
+		}
+	}
+
+	// Close all writers (skip for dry-run)
+	if !s.dryRun {
+		if err := s.writerPool.CloseAll(); err != nil {
+			return fmt.Errorf("error closing writers: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// outputFilename returns the path tableName's output file should be written
+// to, appending the output compression algorithm's conventional extension
+// (e.g. ".gz") when output compression is enabled.
+func (s *Splitter) outputFilename(tableName string) string {
+	if s.maxFileSize > 0 {
+		s.chunkMu.Lock()
+		idx := s.chunkIndex[tableName]
+		s.chunkMu.Unlock()
+		if idx < 1 {
+			idx = 1
+		}
+		return filepath.Join(s.outputDir, fmt.Sprintf("%s.%04d.sql%s", tableName, idx, compress.Extension(s.outputCompression)))
+	}
+	return filepath.Join(s.outputDir, fmt.Sprintf("%s.sql%s", tableName, compress.Extension(s.outputCompression)))
+}
+
+// writeSessionSettings emits the given snapshot of SET statements into a
+// table's freshly-opened file. These are order-independent with respect to
+// CREATE TABLE, unlike the LOCK TABLES line writeLockStatement adds - see
+// writeItem.
+func (s *Splitter) writeSessionSettings(w *writer.TableWriter, tableName string, preamble [][]byte) error {
+	for _, stmt := range preamble {
+		if err := w.WriteStatement(stmt); err != nil {
+			return fmt.Errorf("failed to write session preamble for table %s: %w", tableName, err)
+		}
+	}
+
+	return nil
+}
+
+// writeLockStatement emits a synthesized "LOCK TABLES `t` WRITE;" line, so
+// the table's file can be replayed on its own. The caller must ensure the
+// table's CREATE TABLE has already been written - MySQL rejects LOCK TABLES
+// against a table that doesn't exist yet.
+func (s *Splitter) writeLockStatement(w *writer.TableWriter, tableName string) error {
+	lockStmt := fmt.Sprintf("LOCK TABLES `%s` WRITE;", tableName)
+	if err := w.WriteStatement([]byte(lockStmt)); err != nil {
+		return fmt.Errorf("failed to write lock header for table %s: %w", tableName, err)
+	}
+
+	return nil
+}
+
+// workItem is a single statement routed to a worker for writing.
+type workItem struct {
+	tableName    string
+	stmt         []byte
+	stmtType     parser.StatementType
+	firstSeen    bool
+	preamble     [][]byte // Snapshot of SET statements seen so far; set whenever a header might need writing (see dispatch sites)
+	columns      []string // item.tableName's CREATE TABLE columns, snapshotted when outputFormat != FormatSQL
+	firstDataRow bool     // True for a table's first dispatched Insert when outputFormat != FormatSQL, so its CSV header is written exactly once
+}
+
+// shardFor deterministically maps a table name to one of s.concurrency
+// worker channels, so every statement for that table is handled by the same
+// worker and therefore written to its file in source order.
+func (s *Splitter) shardFor(tableName string) int {
+	if s.concurrency == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(tableName))
+	return int(h.Sum32() % uint32(s.concurrency))
+}
+
+// writeItem gets (or creates) the writer for item's table, writes its
+// session preamble if this is the table's first statement, then writes the
+// statement itself. The preamble's SET statements are order-independent,
+// but its LOCK TABLES must never be written before the table's CREATE
+// TABLE - mysqldump's own LOCK TABLES/UNLOCK TABLES wrapping always comes
+// after the CREATE TABLE it guards, and MySQL itself rejects LOCK TABLES
+// against a table that doesn't exist yet - so CREATE TABLE (replayed via
+// writeChunkHeader on a rotated chunk, or item.stmt itself on a table's
+// first statement) is always written between the two halves of the
+// preamble.
+func (s *Splitter) writeItem(item workItem) error {
+	if s.outputFormat != FormatSQL {
+		return s.writeTransformedItem(item)
+	}
+
+	rotated := false
+	if s.maxFileSize > 0 {
+		var err error
+		rotated, err = s.maybeRotate(item.tableName, int64(len(item.stmt)))
+		if err != nil {
+			return err
+		}
+	}
+
+	filename := s.outputFilename(item.tableName)
+	w, err := s.writerPool.GetWriter(item.tableName, filename)
+	if err != nil {
+		return fmt.Errorf("failed to get writer for table %s: %w", item.tableName, err)
+	}
+
+	needsPreamble := (item.firstSeen || rotated) && s.sessionPreamble
+	if needsPreamble {
+		if err := s.writeSessionSettings(w, item.tableName, item.preamble); err != nil {
+			return err
+		}
+	}
+
+	stmtWritten := false
+	if rotated {
+		if err := s.writeChunkHeader(w, item.tableName); err != nil {
+			return err
+		}
+	} else if item.firstSeen && item.stmtType == parser.CreateTable {
+		if err := w.WriteStatement(item.stmt); err != nil {
+			return fmt.Errorf("failed to write statement for table %s: %w", item.tableName, err)
+		}
+		stmtWritten = true
+	}
+
+	if needsPreamble {
+		if err := s.writeLockStatement(w, item.tableName); err != nil {
+			return err
+		}
+	}
+
+	if !stmtWritten {
+		if err := w.WriteStatement(item.stmt); err != nil {
+			return fmt.Errorf("failed to write statement for table %s: %w", item.tableName, err)
+		}
+	}
+
+	return nil
+}
+
+// maybeRotate closes tableName's current writer and advances it to the
+// next chunk if writing n additional bytes would cross s.maxFileSize. A
+// chunk is never rotated while still empty, so a single statement larger
+// than maxFileSize still gets its own file rather than looping forever.
+// Returns true if a rotation happened.
+func (s *Splitter) maybeRotate(tableName string, n int64) (bool, error) {
+	s.chunkMu.Lock()
+	rotate := s.chunkBytes[tableName] > 0 && s.chunkBytes[tableName]+n > s.maxFileSize
+	if rotate {
+		cur := s.chunkIndex[tableName]
+		if cur < 1 {
+			cur = 1
+		}
+		s.chunkIndex[tableName] = cur + 1
+		s.chunkBytes[tableName] = n
+	} else {
+		s.chunkBytes[tableName] += n
+	}
+	s.chunkMu.Unlock()
+
+	if !rotate {
+		return false, nil
+	}
+
+	// The chunk being rotated away from already has its own LOCK TABLES
+	// header (from writeLockStatement), so it needs the matching UNLOCK
+	// TABLES; footer too - finishWriting only appends that to whichever
+	// chunk is still open at the end of Split.
+	if s.sessionPreamble {
+		if w, exists := s.writerPool.Writer(tableName); exists {
+			if err := w.WriteStatement([]byte("UNLOCK TABLES;")); err != nil {
+				return false, fmt.Errorf("failed to write unlock footer for table %s: %w", tableName, err)
+			}
+		}
+	}
+
+	if err := s.writerPool.CloseWriter(tableName); err != nil {
+		return false, fmt.Errorf("failed to close chunk for table %s: %w", tableName, err)
+	}
+
+	return true, nil
+}
+
+// writeChunkHeader replays tableName's CREATE TABLE statement into a
+// freshly-rotated chunk file, if one was captured, so the chunk can be
+// restored independently of the files before it.
+func (s *Splitter) writeChunkHeader(w *writer.TableWriter, tableName string) error {
+	s.chunkMu.Lock()
+	createStmt := s.createStmts[tableName]
+	s.chunkMu.Unlock()
+
+	if createStmt == nil {
+		return nil
+	}
+	if err := w.WriteStatement(createStmt); err != nil {
+		return fmt.Errorf("failed to write schema header for table %s: %w", tableName, err)
+	}
+
+	return nil
+}
+
+// loadResumeState loads the checkpoint at s.checkpointPath, if any, checks
+// it against file's current size/mtime, seeks file to the checkpointed
+// offset, restores the in-memory stats/tablesSeen/tableStmtCounts/chunk
+// bookkeeping it recorded, and swaps in a WriterPool that appends to each
+// table's existing output file instead of truncating it. Returns the offset
+// to resume from (0 if no checkpoint file exists yet).
+func (s *Splitter) loadResumeState(file afero.File, fileInfo fs.FileInfo) (int64, error) {
+	cp, err := LoadCheckpoint(s.fs, s.checkpointPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	if cp.InputSize != fileInfo.Size() || !cp.InputModTime.Equal(fileInfo.ModTime()) {
+		return 0, fmt.Errorf("splitter: checkpoint %s is stale (input file size/mtime changed since it was written) - rerun with --reset", s.checkpointPath)
+	}
+
+	// Auto doesn't itself name a compressed codec - resolve it by sniffing
+	// the untouched start of the file before seeking, so a plain .sql file
+	// (the common case) isn't rejected just because compression defaults to
+	// Auto.
+	resolvedCompression := s.compression
+	if resolvedCompression == compress.Auto {
+		sniffed, err := compress.Detect(bufio.NewReader(file), s.inputFile)
+		if err != nil {
+			return 0, fmt.Errorf("failed to sniff input compression: %w", err)
+		}
+		resolvedCompression = sniffed
+	}
+	if resolvedCompression != compress.None && resolvedCompression != "" {
+		return 0, fmt.Errorf("splitter: --resume requires --compression=none (cannot seek within a compressed stream)")
+	}
+
+	if _, err := file.Seek(cp.Offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek to checkpoint offset: %w", err)
+	}
+
+	s.mu.Lock()
+	s.stats.StatementsProcessed = cp.StatementsProcessed
+	s.stats.BytesProcessed = cp.BytesProcessed
+	s.stats.TablesFound = len(cp.FinalizedTables)
+	s.stats.TableNames = append([]string(nil), cp.FinalizedTables...)
+	s.mu.Unlock()
+
+	for _, tableName := range cp.FinalizedTables {
+		s.tablesSeen[tableName] = true
+	}
+	for tableName, count := range cp.TableStmtCounts {
+		s.tableStmtCounts[tableName] = count
+	}
+
+	s.chunkMu.Lock()
+	for tableName, idx := range cp.ChunkIndex {
+		s.chunkIndex[tableName] = idx
+	}
+	for tableName, n := range cp.ChunkBytes {
+		s.chunkBytes[tableName] = n
+	}
+	for tableName, stmt := range cp.CreateStmts {
+		s.createStmts[tableName] = stmt
+	}
+	s.chunkMu.Unlock()
+
+	s.writerPool = writer.NewResumableWriterPool(s.fs, s.outputCompression, s.outputCompressLevel)
+
+	return cp.Offset, nil
+}
+
+// splitSequential processes the input on a single goroutine, writing each
+// statement directly to its table's writer before reading the next one, and
+// periodically checkpointing to checkpointPath. This trades the concurrent
+// pipeline's parallel I/O for a guarantee it can't make: by the time a
+// checkpoint is written, every statement up to its offset has already been
+// flushed to disk, so resuming from it can never double-write or skip a
+// statement.
+func (s *Splitter) splitSequential(p *parser.Parser, startOffset int64) error {
+	statementsSinceCheckpoint := 0
 
-	// Process statements sequentially (writes are concurrent via writer pool)
 	for {
 		stmt, err := p.ReadStatement()
 		if err != nil {
@@ -126,6 +785,32 @@ func (s *Splitter) Split() error {
 		// Parse statement type and table name
 		stmtType, tableName := p.ParseStatement(stmt)
 
+		// Accumulate SET statements so they can be replayed into each
+		// table's own file, regardless of table filtering below
+		if s.sessionPreamble && stmtType == parser.Set {
+			s.sessionSetStmts = append(s.sessionSetStmts, append([]byte(nil), stmt...))
+		}
+
+		// mysqldump's conditional-execution-commented session SET statements
+		// are collected regardless of --session-preamble, so they can be
+		// written once to a shared _preamble.sql instead of being duplicated
+		// into every table file
+		if stmtType == parser.SessionSetup {
+			s.sessionSetupStmts = append(s.sessionSetupStmts, append([]byte(nil), stmt...))
+		}
+
+		// Capture each table's CREATE TABLE so it can be replayed into a
+		// rotated chunk, which won't otherwise contain it
+		if s.maxFileSize > 0 && stmtType == parser.CreateTable {
+			s.createStmts[tableName] = append([]byte(nil), stmt...)
+		}
+
+		// Remember each table's columns so they can be attached to its
+		// Insert work items below
+		if s.outputFormat != FormatSQL && stmtType == parser.CreateTable {
+			s.tableColumns[tableName] = parser.ExtractColumnNames(stmt)
+		}
+
 		// Skip unknown statements or those without table names
 		if stmtType == parser.Unknown || tableName == "" {
 			continue
@@ -136,42 +821,103 @@ func (s *Splitter) Split() error {
 			continue
 		}
 
-		// Track unique tables
-		if !s.tablesSeen[tableName] {
+		firstSeen := !s.tablesSeen[tableName]
+
+		s.mu.Lock()
+		if firstSeen {
 			s.tablesSeen[tableName] = true
-			s.mu.Lock()
 			s.stats.TablesFound++
 			s.stats.TableNames = append(s.stats.TableNames, tableName)
-			s.mu.Unlock()
 		}
+		s.stats.StatementsProcessed++
+		s.stats.BytesProcessed += int64(len(stmt))
+		s.mu.Unlock()
 
-		// In dry-run mode, just count without writing
-		if !s.dryRun {
-			// Get or create writer for this table
-			filename := filepath.Join(s.outputDir, fmt.Sprintf("%s.sql", tableName))
-			w, err := s.writerPool.GetWriter(tableName, filename)
-			if err != nil {
-				return fmt.Errorf("failed to get writer for table %s: %w", tableName, err)
-			}
+		if s.dryRun {
+			continue
+		}
 
-			// Write statement (writer handles buffering)
-			if err := w.WriteStatement(stmt); err != nil {
-				return fmt.Errorf("failed to write statement for table %s: %w", tableName, err)
+		item := workItem{tableName: tableName, stmt: stmt, stmtType: stmtType, firstSeen: firstSeen}
+		if s.sessionPreamble && (firstSeen || s.maxFileSize > 0) {
+			item.preamble = append([][]byte(nil), s.sessionSetStmts...)
+		}
+		if s.outputFormat != FormatSQL {
+			item.columns = s.tableColumns[tableName]
+			if stmtType == parser.Insert && !s.tableDataSeen[tableName] {
+				s.tableDataSeen[tableName] = true
+				item.firstDataRow = true
 			}
 		}
+		if err := s.writeItem(item); err != nil {
+			return err
+		}
 
-		// Update stats
 		s.mu.Lock()
-		s.stats.StatementsProcessed++
-		s.stats.BytesProcessed += int64(len(stmt))
+		s.tableStmtCounts[tableName]++
 		s.mu.Unlock()
+
+		statementsSinceCheckpoint++
+		if statementsSinceCheckpoint >= s.checkpointInterval {
+			statementsSinceCheckpoint = 0
+			if err := s.writeCheckpoint(startOffset + p.BytesConsumed()); err != nil {
+				return err
+			}
+		}
 	}
 
-	// Close all writers (skip for dry-run)
+	// Write a final checkpoint so a subsequent --resume reflects a clean
+	// completion
 	if !s.dryRun {
-		if err := s.writerPool.CloseAll(); err != nil {
-			return fmt.Errorf("error closing writers: %w", err)
+		if err := s.writeCheckpoint(startOffset + p.BytesConsumed()); err != nil {
+			return fmt.Errorf("failed to write final checkpoint: %w", err)
+		}
+	}
+
+	return s.finishWriting()
+}
+
+// writeCheckpoint flushes every open table writer - so the recorded offset
+// never points past data that isn't yet durable - and records the current
+// state to checkpointPath.
+func (s *Splitter) writeCheckpoint(offset int64) error {
+	if err := s.writerPool.FlushAll(); err != nil {
+		return fmt.Errorf("failed to flush writers before checkpoint: %w", err)
+	}
+
+	s.mu.Lock()
+	cp := Checkpoint{
+		Offset:              offset,
+		TableStmtCounts:     make(map[string]int64, len(s.tableStmtCounts)),
+		FinalizedTables:     append([]string(nil), s.stats.TableNames...),
+		StatementsProcessed: s.stats.StatementsProcessed,
+		BytesProcessed:      s.stats.BytesProcessed,
+		InputSize:           s.inputSize,
+		InputModTime:        s.inputModTime,
+	}
+	for tableName, count := range s.tableStmtCounts {
+		cp.TableStmtCounts[tableName] = count
+	}
+	s.mu.Unlock()
+
+	if s.maxFileSize > 0 {
+		s.chunkMu.Lock()
+		cp.ChunkIndex = make(map[string]int, len(s.chunkIndex))
+		for tableName, idx := range s.chunkIndex {
+			cp.ChunkIndex[tableName] = idx
 		}
+		cp.ChunkBytes = make(map[string]int64, len(s.chunkBytes))
+		for tableName, n := range s.chunkBytes {
+			cp.ChunkBytes[tableName] = n
+		}
+		cp.CreateStmts = make(map[string][]byte, len(s.createStmts))
+		for tableName, stmt := range s.createStmts {
+			cp.CreateStmts[tableName] = stmt
+		}
+		s.chunkMu.Unlock()
+	}
+
+	if err := WriteCheckpoint(s.fs, s.checkpointPath, cp); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
 	}
 
 	return nil
@@ -1,17 +1,24 @@
 package splitter
 
 import (
-	"os"
-	"path/filepath"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/helgesverre/sql-splitter/internal/compress"
+	"github.com/helgesverre/sql-splitter/internal/parser"
+	"github.com/spf13/afero"
 )
 
 func TestSplitter_Split(t *testing.T) {
-	// Create temp directories
-	tmpDir := t.TempDir()
-	inputFile := filepath.Join(tmpDir, "input.sql")
-	outputDir := filepath.Join(tmpDir, "output")
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
 
 	// Create input file with multiple tables
 	content := `CREATE TABLE users (id INT, name VARCHAR(255));
@@ -22,23 +29,23 @@ INSERT INTO posts VALUES (1, 1, 'Hello World');
 INSERT INTO posts VALUES (2, 2, 'Goodbye World');
 `
 
-	if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write input file: %v", err)
 	}
 
 	// Create splitter and run
-	s := NewSplitter(inputFile, outputDir)
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
 	if err := s.Split(); err != nil {
 		t.Fatalf("split failed: %v", err)
 	}
 
 	// Verify output directory was created
-	if _, err := os.Stat(outputDir); os.IsNotExist(err) {
+	if exists, _ := afero.DirExists(fs, outputDir); !exists {
 		t.Error("output directory was not created")
 	}
 
 	// Verify users.sql was created with correct content
-	usersContent, err := os.ReadFile(filepath.Join(outputDir, "users.sql"))
+	usersContent, err := afero.ReadFile(fs, outputDir+"/users.sql")
 	if err != nil {
 		t.Fatalf("failed to read users.sql: %v", err)
 	}
@@ -56,7 +63,7 @@ INSERT INTO posts VALUES (2, 2, 'Goodbye World');
 	}
 
 	// Verify posts.sql was created with correct content
-	postsContent, err := os.ReadFile(filepath.Join(outputDir, "posts.sql"))
+	postsContent, err := afero.ReadFile(fs, outputDir+"/posts.sql")
 	if err != nil {
 		t.Fatalf("failed to read posts.sql: %v", err)
 	}
@@ -80,15 +87,15 @@ INSERT INTO posts VALUES (2, 2, 'Goodbye World');
 }
 
 func TestSplitter_EmptyFile(t *testing.T) {
-	tmpDir := t.TempDir()
-	inputFile := filepath.Join(tmpDir, "empty.sql")
-	outputDir := filepath.Join(tmpDir, "output")
+	fs := afero.NewMemMapFs()
+	inputFile := "/empty.sql"
+	outputDir := "/output"
 
-	if err := os.WriteFile(inputFile, []byte(""), 0644); err != nil {
+	if err := afero.WriteFile(fs, inputFile, []byte(""), 0644); err != nil {
 		t.Fatalf("failed to write input file: %v", err)
 	}
 
-	s := NewSplitter(inputFile, outputDir)
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
 	if err := s.Split(); err != nil {
 		t.Fatalf("split failed: %v", err)
 	}
@@ -100,10 +107,10 @@ func TestSplitter_EmptyFile(t *testing.T) {
 }
 
 func TestSplitter_FileNotFound(t *testing.T) {
-	tmpDir := t.TempDir()
-	outputDir := filepath.Join(tmpDir, "output")
+	fs := afero.NewMemMapFs()
+	outputDir := "/output"
 
-	s := NewSplitter("/nonexistent/file.sql", outputDir)
+	s := NewSplitter("/nonexistent/file.sql", outputDir, WithFS(fs))
 	err := s.Split()
 	if err == nil {
 		t.Error("expected error for nonexistent file")
@@ -111,9 +118,9 @@ func TestSplitter_FileNotFound(t *testing.T) {
 }
 
 func TestSplitter_UnknownStatements(t *testing.T) {
-	tmpDir := t.TempDir()
-	inputFile := filepath.Join(tmpDir, "input.sql")
-	outputDir := filepath.Join(tmpDir, "output")
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
 
 	// File with unknown statements that should be skipped
 	content := `SELECT * FROM users;
@@ -123,11 +130,11 @@ INSERT INTO users VALUES (1);
 DELETE FROM users WHERE id = 1;
 `
 
-	if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write input file: %v", err)
 	}
 
-	s := NewSplitter(inputFile, outputDir)
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
 	if err := s.Split(); err != nil {
 		t.Fatalf("split failed: %v", err)
 	}
@@ -140,31 +147,31 @@ DELETE FROM users WHERE id = 1;
 }
 
 func TestSplitter_BacktickedTableNames(t *testing.T) {
-	tmpDir := t.TempDir()
-	inputFile := filepath.Join(tmpDir, "input.sql")
-	outputDir := filepath.Join(tmpDir, "output")
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
 
 	content := "CREATE TABLE `my_table` (id INT);\nINSERT INTO `my_table` VALUES (1);\n"
 
-	if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write input file: %v", err)
 	}
 
-	s := NewSplitter(inputFile, outputDir)
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
 	if err := s.Split(); err != nil {
 		t.Fatalf("split failed: %v", err)
 	}
 
 	// Verify my_table.sql was created
-	if _, err := os.Stat(filepath.Join(outputDir, "my_table.sql")); os.IsNotExist(err) {
+	if exists, _ := afero.Exists(fs, outputDir+"/my_table.sql"); !exists {
 		t.Error("my_table.sql was not created")
 	}
 }
 
 func TestSplitter_MultilineStatements(t *testing.T) {
-	tmpDir := t.TempDir()
-	inputFile := filepath.Join(tmpDir, "input.sql")
-	outputDir := filepath.Join(tmpDir, "output")
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
 
 	content := `CREATE TABLE users (
     id INT PRIMARY KEY,
@@ -178,17 +185,17 @@ INSERT INTO users VALUES (
 );
 `
 
-	if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write input file: %v", err)
 	}
 
-	s := NewSplitter(inputFile, outputDir)
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
 	if err := s.Split(); err != nil {
 		t.Fatalf("split failed: %v", err)
 	}
 
 	// Verify users.sql contains both statements
-	usersContent, err := os.ReadFile(filepath.Join(outputDir, "users.sql"))
+	usersContent, err := afero.ReadFile(fs, outputDir+"/users.sql")
 	if err != nil {
 		t.Fatalf("failed to read users.sql: %v", err)
 	}
@@ -202,25 +209,25 @@ INSERT INTO users VALUES (
 }
 
 func TestSplitter_StringsWithSemicolons(t *testing.T) {
-	tmpDir := t.TempDir()
-	inputFile := filepath.Join(tmpDir, "input.sql")
-	outputDir := filepath.Join(tmpDir, "output")
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
 
 	content := `CREATE TABLE logs (id INT, message TEXT);
 INSERT INTO logs VALUES (1, 'Error: semicolon; in message');
 INSERT INTO logs VALUES (2, 'Another; test; message');
 `
 
-	if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write input file: %v", err)
 	}
 
-	s := NewSplitter(inputFile, outputDir)
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
 	if err := s.Split(); err != nil {
 		t.Fatalf("split failed: %v", err)
 	}
 
-	logsContent, err := os.ReadFile(filepath.Join(outputDir, "logs.sql"))
+	logsContent, err := afero.ReadFile(fs, outputDir+"/logs.sql")
 	if err != nil {
 		t.Fatalf("failed to read logs.sql: %v", err)
 	}
@@ -237,20 +244,20 @@ INSERT INTO logs VALUES (2, 'Another; test; message');
 }
 
 func TestSplitter_GetStats(t *testing.T) {
-	tmpDir := t.TempDir()
-	inputFile := filepath.Join(tmpDir, "input.sql")
-	outputDir := filepath.Join(tmpDir, "output")
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
 
 	content := `CREATE TABLE users (id INT);
 INSERT INTO users VALUES (1);
 INSERT INTO users VALUES (2);
 `
 
-	if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write input file: %v", err)
 	}
 
-	s := NewSplitter(inputFile, outputDir)
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
 
 	// Stats should be zero before split
 	stats := s.GetStats()
@@ -272,9 +279,407 @@ INSERT INTO users VALUES (2);
 	}
 }
 
+func TestSplitter_SessionPreamble(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	content := `SET NAMES utf8mb4;
+SET FOREIGN_KEY_CHECKS=0;
+CREATE TABLE users (id INT);
+INSERT INTO users VALUES (1);
+CREATE TABLE posts (id INT);
+INSERT INTO posts VALUES (1);
+`
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithSessionPreamble(true))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	usersContent, err := afero.ReadFile(fs, outputDir+"/users.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.sql: %v", err)
+	}
+	usersStr := string(usersContent)
+
+	if !strings.Contains(usersStr, "SET NAMES utf8mb4;") {
+		t.Error("users.sql should contain the SET NAMES preamble")
+	}
+	if !strings.Contains(usersStr, "SET FOREIGN_KEY_CHECKS=0;") {
+		t.Error("users.sql should contain the SET FOREIGN_KEY_CHECKS preamble")
+	}
+	if !strings.Contains(usersStr, "LOCK TABLES `users` WRITE;") {
+		t.Error("users.sql should contain a LOCK TABLES header")
+	}
+	if !strings.Contains(usersStr, "UNLOCK TABLES;") {
+		t.Error("users.sql should contain an UNLOCK TABLES footer")
+	}
+
+	// CREATE TABLE must precede LOCK TABLES - mysql rejects LOCK TABLES
+	// against a table that doesn't exist yet, so replaying users.sql on its
+	// own (the feature's whole point) would otherwise fail.
+	if createIdx, lockIdx := strings.Index(usersStr, "CREATE TABLE users"), strings.Index(usersStr, "LOCK TABLES `users` WRITE;"); createIdx == -1 || lockIdx == -1 || createIdx > lockIdx {
+		t.Errorf("expected CREATE TABLE before LOCK TABLES in users.sql, got:\n%s", usersStr)
+	}
+
+	postsContent, err := afero.ReadFile(fs, outputDir+"/posts.sql")
+	if err != nil {
+		t.Fatalf("failed to read posts.sql: %v", err)
+	}
+	postsStr := string(postsContent)
+
+	if !strings.Contains(postsStr, "LOCK TABLES `posts` WRITE;") {
+		t.Error("posts.sql should contain a LOCK TABLES header")
+	}
+	if !strings.Contains(postsStr, "UNLOCK TABLES;") {
+		t.Error("posts.sql should contain an UNLOCK TABLES footer")
+	}
+	if createIdx, lockIdx := strings.Index(postsStr, "CREATE TABLE posts"), strings.Index(postsStr, "LOCK TABLES `posts` WRITE;"); createIdx == -1 || lockIdx == -1 || createIdx > lockIdx {
+		t.Errorf("expected CREATE TABLE before LOCK TABLES in posts.sql, got:\n%s", postsStr)
+	}
+}
+
+func TestSplitter_SessionSetupPreambleFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	content := `/*!40101 SET NAMES utf8mb4 */;
+/*!40103 SET TIME_ZONE='+00:00' */;
+CREATE /*!32312 IF NOT EXISTS*/ TABLE ` + "`users`" + ` (id INT);
+INSERT INTO users VALUES (1);
+`
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	// This doesn't need WithSessionPreamble: the shared _preamble.sql file is
+	// written whenever session-setup SET statements are seen, independent of
+	// that per-table-replay feature.
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	preambleContent, err := afero.ReadFile(fs, outputDir+"/_preamble.sql")
+	if err != nil {
+		t.Fatalf("failed to read _preamble.sql: %v", err)
+	}
+	preambleStr := string(preambleContent)
+	if !strings.Contains(preambleStr, "/*!40101 SET NAMES utf8mb4 */;") {
+		t.Error("_preamble.sql should contain the SET NAMES statement")
+	}
+	if !strings.Contains(preambleStr, "/*!40103 SET TIME_ZONE='+00:00' */;") {
+		t.Error("_preamble.sql should contain the SET TIME_ZONE statement")
+	}
+
+	usersContent, err := afero.ReadFile(fs, outputDir+"/users.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.sql: %v", err)
+	}
+	usersStr := string(usersContent)
+	if !strings.Contains(usersStr, "CREATE /*!32312 IF NOT EXISTS*/ TABLE `users` (id INT);") {
+		t.Error("users.sql should contain the CREATE TABLE statement, despite its executable comment, byte-identical to the input")
+	}
+	if strings.Contains(usersStr, "SET NAMES") {
+		t.Error("users.sql should not contain the session-setup SET statements - those belong only in _preamble.sql")
+	}
+}
+
+func TestSplitter_MaxFileSizeRotatesChunks(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	var content strings.Builder
+	content.WriteString("CREATE TABLE users (id INT);\n")
+	for i := 0; i < 20; i++ {
+		content.WriteString("INSERT INTO users VALUES (1);\n")
+	}
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content.String()), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	// Small enough that "INSERT INTO users VALUES (1);" statements rotate
+	// after only a few per chunk, but large enough to hold more than one.
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithMaxFileSize(100))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	chunk1, err := afero.ReadFile(fs, outputDir+"/users.0001.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.0001.sql: %v", err)
+	}
+	if !strings.Contains(string(chunk1), "CREATE TABLE users") {
+		t.Error("users.0001.sql should contain the CREATE TABLE statement")
+	}
+
+	chunk2, err := afero.ReadFile(fs, outputDir+"/users.0002.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.0002.sql: %v", err)
+	}
+	if !strings.Contains(string(chunk2), "CREATE TABLE users") {
+		t.Error("users.0002.sql should also contain the replayed CREATE TABLE statement")
+	}
+	if !strings.Contains(string(chunk2), "INSERT INTO users") {
+		t.Error("users.0002.sql should contain its share of INSERT statements")
+	}
+
+	if exists, _ := afero.Exists(fs, outputDir+"/users.sql"); exists {
+		t.Error("unchunked users.sql should not be created when WithMaxFileSize is set")
+	}
+}
+
+func TestSplitter_MaxFileSizeWithSessionPreamble(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	var content strings.Builder
+	content.WriteString("SET NAMES utf8mb4;\n")
+	content.WriteString("CREATE TABLE users (id INT);\n")
+	for i := 0; i < 20; i++ {
+		content.WriteString("INSERT INTO users VALUES (1);\n")
+	}
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content.String()), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithMaxFileSize(100), WithSessionPreamble(true))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	chunk1, err := afero.ReadFile(fs, outputDir+"/users.0001.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.0001.sql: %v", err)
+	}
+	chunk1Str := string(chunk1)
+	if !strings.Contains(chunk1Str, "LOCK TABLES `users` WRITE;") {
+		t.Error("users.0001.sql should contain a LOCK TABLES header")
+	}
+	if !strings.Contains(chunk1Str, "UNLOCK TABLES;") {
+		t.Error("users.0001.sql should contain an UNLOCK TABLES footer now that it's been rotated away from")
+	}
+	if createIdx, lockIdx := strings.Index(chunk1Str, "CREATE TABLE users"), strings.Index(chunk1Str, "LOCK TABLES `users` WRITE;"); createIdx == -1 || lockIdx == -1 || createIdx > lockIdx {
+		t.Errorf("expected CREATE TABLE before LOCK TABLES in users.0001.sql, got:\n%s", chunk1Str)
+	}
+
+	chunk2, err := afero.ReadFile(fs, outputDir+"/users.0002.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.0002.sql: %v", err)
+	}
+	chunk2Str := string(chunk2)
+	if !strings.Contains(chunk2Str, "SET NAMES utf8mb4;") {
+		t.Error("users.0002.sql should contain the replayed SET preamble")
+	}
+	if !strings.Contains(chunk2Str, "LOCK TABLES `users` WRITE;") {
+		t.Error("users.0002.sql should contain a LOCK TABLES header")
+	}
+	if !strings.Contains(chunk2Str, "UNLOCK TABLES;") {
+		t.Error("users.0002.sql should contain an UNLOCK TABLES footer")
+	}
+	if createIdx, lockIdx := strings.Index(chunk2Str, "CREATE TABLE users"), strings.Index(chunk2Str, "LOCK TABLES `users` WRITE;"); createIdx == -1 || lockIdx == -1 || createIdx > lockIdx {
+		t.Errorf("expected the replayed CREATE TABLE before LOCK TABLES in users.0002.sql, got:\n%s", chunk2Str)
+	}
+}
+
+func TestSplitter_PostgresDialectCopyFromStdin(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	content := "CREATE TABLE public.users (id integer, name text);\n" +
+		"COPY public.users (id, name) FROM stdin;\n" +
+		"1\tAlice\n" +
+		"2\tBob\n" +
+		"\\.\n" +
+		"\n" +
+		"CREATE TABLE public.posts (id integer, title text);\n" +
+		"INSERT INTO public.posts VALUES (1, 'Hello; World');\n"
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithDialect(parser.Postgres))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	usersContent, err := afero.ReadFile(fs, outputDir+"/public.users.sql")
+	if err != nil {
+		t.Fatalf("failed to read public.users.sql: %v", err)
+	}
+	usersStr := string(usersContent)
+	if !strings.Contains(usersStr, "COPY public.users (id, name) FROM stdin;") {
+		t.Error("public.users.sql should contain the COPY header")
+	}
+	if !strings.Contains(usersStr, "1\tAlice\n2\tBob\n\\.") {
+		t.Error("public.users.sql should contain the COPY data rows and terminator")
+	}
+
+	postsContent, err := afero.ReadFile(fs, outputDir+"/public.posts.sql")
+	if err != nil {
+		t.Fatalf("failed to read public.posts.sql: %v", err)
+	}
+	if !strings.Contains(string(postsContent), "INSERT INTO public.posts VALUES (1, 'Hello; World');") {
+		t.Error("public.posts.sql should contain the INSERT with its embedded semicolon intact")
+	}
+}
+
+func TestSplitter_OutputFormatCSV(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	content := "CREATE TABLE users (id INT, name VARCHAR(255));\n" +
+		"INSERT INTO users VALUES (1, 'alice'), (2, 'bob, jr');\n" +
+		"ALTER TABLE users ADD INDEX idx_name (name);\n"
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithOutputFormat(FormatCSV))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	csvContent, err := afero.ReadFile(fs, outputDir+"/users.csv")
+	if err != nil {
+		t.Fatalf("failed to read users.csv: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(csvContent), "\n"), "\n")
+	want := []string{"id,name", "1,alice", `2,"bob, jr"`}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("users.csv lines:\nwant: %#v\ngot:  %#v", want, lines)
+	}
+
+	schemaContent, err := afero.ReadFile(fs, outputDir+"/users.schema.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.schema.sql: %v", err)
+	}
+	schemaStr := string(schemaContent)
+	if !strings.Contains(schemaStr, "CREATE TABLE users (id INT, name VARCHAR(255));") {
+		t.Error("users.schema.sql should contain the CREATE TABLE statement")
+	}
+	if !strings.Contains(schemaStr, "ALTER TABLE users ADD INDEX idx_name (name);") {
+		t.Error("users.schema.sql should contain the ALTER TABLE statement")
+	}
+
+	exists, err := afero.Exists(fs, outputDir+"/users.sql")
+	if err != nil {
+		t.Fatalf("failed to check users.sql: %v", err)
+	}
+	if exists {
+		t.Error("users.sql should not be created when --format=csv routes output elsewhere")
+	}
+}
+
+func TestSplitter_OutputFormatJSONL(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	content := "CREATE TABLE users (id INT, name VARCHAR(255));\n" +
+		"INSERT INTO users VALUES (1, 'alice'), (2, NULL);\n"
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithOutputFormat(FormatJSONL))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	jsonlContent, err := afero.ReadFile(fs, outputDir+"/users.jsonl")
+	if err != nil {
+		t.Fatalf("failed to read users.jsonl: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(jsonlContent), "\n"), "\n")
+	want := []string{`{"id":1,"name":"alice"}`, `{"id":2,"name":null}`}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("users.jsonl lines:\nwant: %#v\ngot:  %#v", want, lines)
+	}
+}
+
+func TestSplitter_OutputFormatJSONLBareNonNumericLiteral(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	content := "CREATE TABLE flags (id INT, active BIT(1));\n" +
+		"INSERT INTO flags VALUES (1, b'1');\n"
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithOutputFormat(FormatJSONL))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	jsonlContent, err := afero.ReadFile(fs, outputDir+"/flags.jsonl")
+	if err != nil {
+		t.Fatalf("failed to read flags.jsonl: %v", err)
+	}
+	line := strings.TrimRight(string(jsonlContent), "\n")
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+		t.Fatalf("flags.jsonl line is not valid JSON: %v (line: %s)", err, line)
+	}
+	if decoded["active"] != "b'1'" {
+		t.Errorf("want active to fall back to its source text as a JSON string, got %#v", decoded["active"])
+	}
+}
+
+// TestSplitter_OutputFormatWithSessionPreambleSkipsUnlockFooter guards
+// against a regression where finishWriting's UNLOCK TABLES; footer (for
+// WithSessionPreamble) was appended to the CSV/JSONL data file instead of
+// being skipped, since both share the same writer-pool key (tableName).
+func TestSplitter_OutputFormatWithSessionPreambleSkipsUnlockFooter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	content := "SET NAMES utf8mb4;\n" +
+		"CREATE TABLE users (id INT, name VARCHAR(255));\n" +
+		"INSERT INTO users VALUES (1, 'alice');\n"
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithOutputFormat(FormatCSV), WithSessionPreamble(true))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	csvContent, err := afero.ReadFile(fs, outputDir+"/users.csv")
+	if err != nil {
+		t.Fatalf("failed to read users.csv: %v", err)
+	}
+	if strings.Contains(string(csvContent), "UNLOCK TABLES") {
+		t.Errorf("users.csv should not contain the SQL-only session-preamble UNLOCK TABLES footer, got:\n%s", csvContent)
+	}
+}
+
 func BenchmarkSplitter_Split(b *testing.B) {
-	tmpDir := b.TempDir()
-	inputFile := filepath.Join(tmpDir, "bench.sql")
+	fs := afero.NewMemMapFs()
+	inputFile := "/bench.sql"
 
 	// Create file with many statements
 	var content strings.Builder
@@ -283,7 +688,7 @@ func BenchmarkSplitter_Split(b *testing.B) {
 		content.WriteString("INSERT INTO users VALUES (1);\n")
 	}
 
-	if err := os.WriteFile(inputFile, []byte(content.String()), 0644); err != nil {
+	if err := afero.WriteFile(fs, inputFile, []byte(content.String()), 0644); err != nil {
 		b.Fatalf("failed to write test file: %v", err)
 	}
 
@@ -291,10 +696,312 @@ func BenchmarkSplitter_Split(b *testing.B) {
 	b.SetBytes(int64(content.Len()))
 
 	for i := 0; i < b.N; i++ {
-		outputDir := filepath.Join(tmpDir, "output", string(rune(i)))
-		s := NewSplitter(inputFile, outputDir)
+		outputDir := fmt.Sprintf("/output/%d", i)
+		s := NewSplitter(inputFile, outputDir, WithFS(fs))
 		if err := s.Split(); err != nil {
 			b.Fatal(err)
 		}
 	}
 }
+
+// BenchmarkSplitter_SplitConcurrent measures the producer/consumer pipeline
+// (one goroutine reading/classifying, WithConcurrency workers writing)
+// against a dump with several tables, where the serial BenchmarkSplitter_Split
+// above exercises only a single table and therefore never engages more than
+// one worker.
+func BenchmarkSplitter_SplitConcurrent(b *testing.B) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/bench-concurrent.sql"
+
+	var content strings.Builder
+	tables := []string{"users", "posts", "comments", "likes"}
+	for _, table := range tables {
+		content.WriteString("CREATE TABLE " + table + " (id INT);\n")
+	}
+	for i := 0; i < 4000; i++ {
+		table := tables[i%len(tables)]
+		content.WriteString("INSERT INTO " + table + " VALUES (1);\n")
+	}
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content.String()), 0644); err != nil {
+		b.Fatalf("failed to write test file: %v", err)
+	}
+
+	b.ResetTimer()
+	b.SetBytes(int64(content.Len()))
+
+	for i := 0; i < b.N; i++ {
+		outputDir := fmt.Sprintf("/output/%d", i)
+		s := NewSplitter(inputFile, outputDir, WithFS(fs), WithConcurrency(4))
+		if err := s.Split(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSplitter_OutputCompression(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	content := `CREATE TABLE users (id INT);
+INSERT INTO users VALUES (1);
+`
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithOutputCompression(compress.Gzip, 0))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	compressed, err := afero.ReadFile(fs, outputDir+"/users.sql.gz")
+	if err != nil {
+		t.Fatalf("failed to read users.sql.gz: %v", err)
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("expected a valid gzip stream: %v", err)
+	}
+	defer gr.Close()
+
+	decompressed, err := io.ReadAll(gr)
+	if err != nil {
+		t.Fatalf("failed to read gzip content: %v", err)
+	}
+
+	if !strings.Contains(string(decompressed), "INSERT INTO users VALUES (1);") {
+		t.Error("decompressed users.sql.gz should contain the INSERT statement")
+	}
+}
+
+func TestSplitter_InputDecompression(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql.gz"
+	outputDir := "/output"
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write([]byte("CREATE TABLE users (id INT);\nINSERT INTO users VALUES (1);\n")); err != nil {
+		t.Fatalf("failed to write gzip content: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	if err := afero.WriteFile(fs, inputFile, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	usersContent, err := afero.ReadFile(fs, outputDir+"/users.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.sql: %v", err)
+	}
+	if !strings.Contains(string(usersContent), "INSERT INTO users VALUES (1);") {
+		t.Error("users.sql should contain the INSERT statement from the decompressed input")
+	}
+}
+
+func TestSplitter_ConcurrentWorkersPreserveOrder(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+
+	var content strings.Builder
+	tables := []string{"users", "posts", "comments", "likes"}
+	for _, table := range tables {
+		content.WriteString("CREATE TABLE " + table + " (id INT);\n")
+	}
+	for i := 0; i < 200; i++ {
+		table := tables[i%len(tables)]
+		content.WriteString(fmt.Sprintf("INSERT INTO %s VALUES (%d);\n", table, i))
+	}
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content.String()), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithConcurrency(4))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	for _, table := range tables {
+		tableContent, err := afero.ReadFile(fs, outputDir+"/"+table+".sql")
+		if err != nil {
+			t.Fatalf("failed to read %s.sql: %v", table, err)
+		}
+
+		lastValue := -1
+		for _, line := range strings.Split(string(tableContent), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if !strings.HasPrefix(line, "CREATE TABLE "+table+" ") && !strings.HasPrefix(line, "INSERT INTO "+table+" VALUES") {
+				t.Errorf("%s.sql: found statement for another table interleaved: %q", table, line)
+			}
+			var value int
+			if _, err := fmt.Sscanf(line, "INSERT INTO "+table+" VALUES (%d);", &value); err == nil {
+				if value <= lastValue {
+					t.Errorf("%s.sql: INSERT with value %d written after value %d, out of source order", table, value, lastValue)
+				}
+				lastValue = value
+			}
+		}
+
+		insertCount := strings.Count(string(tableContent), "INSERT INTO "+table)
+		if insertCount != 50 {
+			t.Errorf("%s.sql: expected 50 inserts, got %d", table, insertCount)
+		}
+	}
+
+	stats := s.GetStats()
+	if stats.StatementsProcessed != int64(len(tables)+200) {
+		t.Errorf("expected %d statements processed, got %d", len(tables)+200, stats.StatementsProcessed)
+	}
+}
+
+func TestSplitter_CheckpointResume(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+	checkpointFile := "/checkpoint.json"
+
+	var content strings.Builder
+	content.WriteString("CREATE TABLE users (id INT);\n")
+	for i := 0; i < 50; i++ {
+		content.WriteString("INSERT INTO users VALUES (1);\n")
+	}
+	if err := afero.WriteFile(fs, inputFile, []byte(content.String()), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithCheckpoint(checkpointFile, 10))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+	fullStats := s.GetStats()
+
+	if exists, err := afero.Exists(fs, checkpointFile); err != nil || !exists {
+		t.Fatalf("expected checkpoint file to be written: exists=%v err=%v", exists, err)
+	}
+
+	fullContent, err := afero.ReadFile(fs, outputDir+"/users.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.sql: %v", err)
+	}
+
+	// Resuming from the final checkpoint (the whole file already consumed)
+	// should leave the output unchanged and reproduce identical stats
+	// without writing any statement twice.
+	resumed := NewSplitter(inputFile, outputDir, WithFS(fs), WithCheckpoint(checkpointFile, 10), WithResume(true))
+	if err := resumed.Split(); err != nil {
+		t.Fatalf("resumed split failed: %v", err)
+	}
+	resumedStats := resumed.GetStats()
+
+	if resumedStats.StatementsProcessed != fullStats.StatementsProcessed {
+		t.Errorf("expected %d statements processed after resume, got %d", fullStats.StatementsProcessed, resumedStats.StatementsProcessed)
+	}
+
+	resumedContent, err := afero.ReadFile(fs, outputDir+"/users.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.sql after resume: %v", err)
+	}
+	if string(resumedContent) != string(fullContent) {
+		t.Error("resuming from a checkpoint at EOF should not change the output file")
+	}
+}
+
+func TestSplitter_CheckpointResumeWithMaxFileSize(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+	checkpointFile := "/checkpoint.json"
+
+	createStmt := "CREATE TABLE users (id INT);\n"
+	insertStmt := "INSERT INTO users VALUES (1);\n"
+	content := createStmt + insertStmt
+
+	if err := afero.WriteFile(fs, inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+	fileInfo, err := fs.Stat(inputFile)
+	if err != nil {
+		t.Fatalf("failed to stat input file: %v", err)
+	}
+
+	// Forge a checkpoint as if a prior run had already rotated users.sql to
+	// its third chunk (e.g. from statements earlier in a larger file this
+	// test doesn't bother simulating) before being interrupted right after
+	// the CREATE TABLE - so the only thing left to process on resume is the
+	// trailing INSERT.
+	cp := Checkpoint{
+		Offset:              int64(len(createStmt)),
+		TableStmtCounts:     map[string]int64{"users": 1},
+		FinalizedTables:     []string{"users"},
+		StatementsProcessed: 1,
+		BytesProcessed:      int64(len(createStmt)),
+		InputSize:           fileInfo.Size(),
+		InputModTime:        fileInfo.ModTime(),
+		ChunkIndex:          map[string]int{"users": 3},
+		ChunkBytes:          map[string]int64{"users": int64(len(createStmt))},
+		CreateStmts:         map[string][]byte{"users": []byte("CREATE TABLE users (id INT);")},
+	}
+	if err := WriteCheckpoint(fs, checkpointFile, cp); err != nil {
+		t.Fatalf("failed to write forged checkpoint: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithMaxFileSize(1000), WithCheckpoint(checkpointFile, 1), WithResume(true))
+	if err := s.Split(); err != nil {
+		t.Fatalf("resumed split failed: %v", err)
+	}
+
+	// The trailing INSERT must land in users.0003.sql, continuing the
+	// forged chunk index, rather than resetting to users.0001.sql.
+	if exists, _ := afero.Exists(fs, outputDir+"/users.0001.sql"); exists {
+		t.Error("resume should not have restarted users at chunk 1")
+	}
+	chunk3, err := afero.ReadFile(fs, outputDir+"/users.0003.sql")
+	if err != nil {
+		t.Fatalf("failed to read users.0003.sql: %v", err)
+	}
+	if !strings.Contains(string(chunk3), "INSERT INTO users VALUES (1);") {
+		t.Errorf("expected the resumed INSERT in users.0003.sql, got:\n%s", string(chunk3))
+	}
+}
+
+func TestSplitter_ResumeRejectsStaleCheckpoint(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	inputFile := "/input.sql"
+	outputDir := "/output"
+	checkpointFile := "/checkpoint.json"
+
+	if err := afero.WriteFile(fs, inputFile, []byte("CREATE TABLE users (id INT);\n"), 0644); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	s := NewSplitter(inputFile, outputDir, WithFS(fs), WithCheckpoint(checkpointFile, 1))
+	if err := s.Split(); err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	// Appending to the input after the checkpoint was written changes its
+	// size, so a resume should refuse to continue against stale data.
+	if err := afero.WriteFile(fs, inputFile, []byte("CREATE TABLE users (id INT);\nINSERT INTO users VALUES (1);\n"), 0644); err != nil {
+		t.Fatalf("failed to modify input file: %v", err)
+	}
+
+	resumed := NewSplitter(inputFile, outputDir, WithFS(fs), WithCheckpoint(checkpointFile, 1), WithResume(true))
+	if err := resumed.Split(); err == nil {
+		t.Error("expected an error resuming from a checkpoint made stale by a changed input file")
+	}
+}
@@ -0,0 +1,238 @@
+package splitter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/helgesverre/sql-splitter/internal/compress"
+	"github.com/helgesverre/sql-splitter/internal/parser"
+)
+
+// OutputFormat selects how Insert rows are written to each table's output
+// file. See WithOutputFormat.
+type OutputFormat string
+
+const (
+	FormatSQL   OutputFormat = "sql"
+	FormatCSV   OutputFormat = "csv"
+	FormatJSONL OutputFormat = "jsonl"
+)
+
+// writeTransformedItem routes item to its table's schema file (DDL,
+// written byte-for-byte) or data file (Insert, transformed into one
+// CSV/JSONL row per VALUES tuple) depending on s.outputFormat. Chunking
+// (WithMaxFileSize) and the session preamble/LOCK TABLES wrapping
+// (WithSessionPreamble) only apply to FormatSQL, so neither is consulted
+// here.
+func (s *Splitter) writeTransformedItem(item workItem) error {
+	if item.stmtType != parser.Insert {
+		filename := filepath.Join(s.outputDir, item.tableName+".schema.sql"+compress.Extension(s.outputCompression))
+		w, err := s.writerPool.GetWriter(item.tableName+":schema", filename)
+		if err != nil {
+			return fmt.Errorf("failed to get schema writer for table %s: %w", item.tableName, err)
+		}
+		if err := w.WriteStatement(item.stmt); err != nil {
+			return fmt.Errorf("failed to write schema statement for table %s: %w", item.tableName, err)
+		}
+		return nil
+	}
+
+	filename := filepath.Join(s.outputDir, item.tableName+"."+string(s.outputFormat)+compress.Extension(s.outputCompression))
+	w, err := s.writerPool.GetWriter(item.tableName, filename)
+	if err != nil {
+		return fmt.Errorf("failed to get data writer for table %s: %w", item.tableName, err)
+	}
+
+	columns := parser.ExtractInsertColumns(item.stmt)
+	if columns == nil {
+		columns = item.columns
+	}
+
+	if s.outputFormat == FormatCSV && item.firstDataRow && len(columns) > 0 {
+		if err := w.WriteStatement([]byte(csvEncodeRow(columns))); err != nil {
+			return fmt.Errorf("failed to write CSV header for table %s: %w", item.tableName, err)
+		}
+	}
+
+	it := parser.NewValuesIterator(item.stmt)
+	for {
+		row, ok := it.Next()
+		if !ok {
+			break
+		}
+
+		var line string
+		switch s.outputFormat {
+		case FormatCSV:
+			line = csvEncodeRow(csvRowValues(row))
+		case FormatJSONL:
+			line, err = jsonEncodeRow(columns, row)
+			if err != nil {
+				return fmt.Errorf("failed to encode JSON row for table %s: %w", item.tableName, err)
+			}
+		}
+
+		if err := w.WriteStatement([]byte(line)); err != nil {
+			return fmt.Errorf("failed to write row for table %s: %w", item.tableName, err)
+		}
+	}
+
+	return nil
+}
+
+// decodedValue is a single VALUES tuple element, decoded from its raw SQL
+// literal form ('quoted', NULL, or a bare number/keyword) for CSV/JSONL
+// output.
+type decodedValue struct {
+	isNull bool
+	text   string
+	quoted bool // true for a quoted string literal; false for NULL/bare literals
+}
+
+func decodeValue(raw []byte) decodedValue {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) >= 2 && (raw[0] == '\'' || raw[0] == '"') && raw[len(raw)-1] == raw[0] {
+		return decodedValue{text: string(unescapeSQLString(raw[1 : len(raw)-1])), quoted: true}
+	}
+	if bytes.EqualFold(raw, []byte("NULL")) {
+		return decodedValue{isNull: true}
+	}
+	return decodedValue{text: string(raw)}
+}
+
+// unescapeSQLString reverses the backslash-escaping that ValuesIterator's
+// quote-tracking recognizes (\', \", \\, \n, \r, \t, \0, \Z, \b) so the
+// value written to CSV/JSONL is the value MySQL would actually store,
+// rather than its literal source form.
+func unescapeSQLString(inner []byte) []byte {
+	if bytes.IndexByte(inner, '\\') == -1 {
+		return inner
+	}
+
+	out := make([]byte, 0, len(inner))
+	for i := 0; i < len(inner); i++ {
+		b := inner[i]
+		if b == '\\' && i+1 < len(inner) {
+			i++
+			switch inner[i] {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case '0':
+				out = append(out, 0)
+			case 'Z':
+				out = append(out, 26)
+			case 'b':
+				out = append(out, '\b')
+			default:
+				out = append(out, inner[i])
+			}
+			continue
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// isJSONLiteral reports whether text is already valid standalone JSON (a
+// number or a TRUE/FALSE keyword), so jsonEncodeRow can write it verbatim
+// instead of quoting it as a string.
+func isJSONLiteral(text string) bool {
+	if strings.EqualFold(text, "true") || strings.EqualFold(text, "false") {
+		return true
+	}
+	_, err := strconv.ParseFloat(text, 64)
+	return err == nil && json.Valid([]byte(text))
+}
+
+// csvRowValues decodes row's raw column values for CSV output. NULL
+// becomes an empty field - CSV has no null literal of its own, and an
+// empty field is the common ETL convention for it.
+func csvRowValues(row [][]byte) []string {
+	values := make([]string, len(row))
+	for i, raw := range row {
+		dv := decodeValue(raw)
+		if !dv.isNull {
+			values[i] = dv.text
+		}
+	}
+	return values
+}
+
+// csvEncodeRow renders values as one RFC 4180 CSV record. The caller's
+// writer appends the trailing newline.
+func csvEncodeRow(values []string) string {
+	var b strings.Builder
+	for i, v := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if strings.ContainsAny(v, ",\"\n\r") {
+			b.WriteByte('"')
+			b.WriteString(strings.ReplaceAll(v, `"`, `""`))
+			b.WriteByte('"')
+		} else {
+			b.WriteString(v)
+		}
+	}
+	return b.String()
+}
+
+// jsonEncodeRow renders columns/values as one JSON object, preserving
+// column order - unlike json.Marshal on a map, which would sort the keys
+// alphabetically. Values past the last known column name fall back to
+// "column_N".
+func jsonEncodeRow(columns []string, values [][]byte) (string, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, raw := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key := fmt.Sprintf("column_%d", i)
+		if i < len(columns) {
+			key = columns[i]
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return "", err
+		}
+		b.Write(keyJSON)
+		b.WriteByte(':')
+
+		dv := decodeValue(raw)
+		switch {
+		case dv.isNull:
+			b.WriteString("null")
+		case dv.quoted:
+			valJSON, err := json.Marshal(dv.text)
+			if err != nil {
+				return "", err
+			}
+			b.Write(valJSON)
+		case isJSONLiteral(dv.text):
+			// A bare number or TRUE/FALSE, written verbatim.
+			b.WriteString(strings.ToLower(dv.text))
+		default:
+			// An unquoted literal that isn't valid JSON on its own - e.g. a
+			// MySQL bit-value literal like b'1', or a function call a dump
+			// left un-evaluated. Fall back to its source text as a JSON
+			// string rather than emitting invalid JSON.
+			valJSON, err := json.Marshal(dv.text)
+			if err != nil {
+				return "", err
+			}
+			b.Write(valJSON)
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
@@ -2,8 +2,13 @@ package writer
 
 import (
 	"bufio"
+	"fmt"
+	"io"
 	"os"
 	"sync"
+
+	"github.com/helgesverre/sql-splitter/internal/compress"
+	"github.com/spf13/afero"
 )
 
 const (
@@ -16,17 +21,59 @@ const (
 
 // TableWriter handles buffered writing to table-specific output files
 type TableWriter struct {
-	file          *os.File
-	writer        *bufio.Writer
-	stmtBuffer    [][]byte
-	maxStmtBuffer int
-	writeCount    int // Track writes for auto-flush
-	mu            sync.Mutex
+	file           afero.File
+	compressCloser io.Closer // non-nil when output compression is enabled; finalizes the compressed stream on Close
+	writer         *bufio.Writer
+	stmtBuffer     [][]byte
+	maxStmtBuffer  int
+	writeCount     int // Track writes for auto-flush
+	mu             sync.Mutex
+}
+
+// NewTableWriter creates a new table writer for the given filename on fs.
+// When algo is not compress.None, writes are transparently compressed at
+// the given level (algorithm-specific; 0 means the algorithm's default)
+// before hitting disk.
+func NewTableWriter(fs afero.Fs, filename string, algo compress.Algorithm, level int) (*TableWriter, error) {
+	file, err := fs.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	var out io.Writer = file
+	var compressCloser io.Closer
+	if algo != compress.None && algo != "" {
+		cw, err := compress.WrapWriter(file, algo, level)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		out = cw
+		compressCloser = cw
+	}
+
+	writer := bufio.NewWriterSize(out, WriterBufferSize)
+
+	return &TableWriter{
+		file:           file,
+		compressCloser: compressCloser,
+		writer:         writer,
+		stmtBuffer:     make([][]byte, 0, StmtBufferCount),
+		maxStmtBuffer:  StmtBufferCount,
+	}, nil
 }
 
-// NewTableWriter creates a new table writer for the given filename
-func NewTableWriter(filename string) (*TableWriter, error) {
-	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+// NewTableWriterAppend is like NewTableWriter, but opens filename for append
+// instead of truncating it, so a partially-written table file left behind by
+// an interrupted run can be continued rather than overwritten. Output
+// compression isn't supported here: most codecs can't be resumed by a naive
+// byte-level append, so algo must be compress.None (or unset).
+func NewTableWriterAppend(fs afero.Fs, filename string, algo compress.Algorithm, level int) (*TableWriter, error) {
+	if algo != compress.None && algo != "" {
+		return nil, fmt.Errorf("writer: cannot append to a compressed output file (%s)", algo)
+	}
+
+	file, err := fs.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
 		return nil, err
 	}
@@ -97,23 +144,48 @@ func (w *TableWriter) Close() error {
 		return err
 	}
 
+	// Finalize the compressed stream (if any) before closing the file
+	if w.compressCloser != nil {
+		if err := w.compressCloser.Close(); err != nil {
+			return err
+		}
+	}
+
 	// Close file
 	return w.file.Close()
 }
 
 // WriterPool manages a pool of table writers for concurrent access
 type WriterPool struct {
-	writers map[string]*TableWriter
-	mu      sync.RWMutex
+	fs            afero.Fs
+	compression   compress.Algorithm
+	compressLevel int
+	appendMode    bool // When true, GetWriter opens files for append instead of truncating them
+	writers       map[string]*TableWriter
+	mu            sync.RWMutex
 }
 
-// NewWriterPool creates a new writer pool
-func NewWriterPool() *WriterPool {
+// NewWriterPool creates a new writer pool backed by fs. Every writer it
+// creates compresses its output with algo at level (see NewTableWriter).
+func NewWriterPool(fs afero.Fs, algo compress.Algorithm, level int) *WriterPool {
 	return &WriterPool{
-		writers: make(map[string]*TableWriter),
+		fs:            fs,
+		compression:   algo,
+		compressLevel: level,
+		writers:       make(map[string]*TableWriter),
 	}
 }
 
+// NewResumableWriterPool is like NewWriterPool, but every writer it creates
+// opens its file for append instead of truncating it, so a split resumed
+// from a checkpoint continues a table's existing output file rather than
+// overwriting it.
+func NewResumableWriterPool(fs afero.Fs, algo compress.Algorithm, level int) *WriterPool {
+	pool := NewWriterPool(fs, algo, level)
+	pool.appendMode = true
+	return pool
+}
+
 // GetWriter returns a writer for the given table, creating it if necessary
 func (p *WriterPool) GetWriter(tableName, filename string) (*TableWriter, error) {
 	// Try read lock first (fast path)
@@ -135,7 +207,12 @@ func (p *WriterPool) GetWriter(tableName, filename string) (*TableWriter, error)
 	}
 
 	// Create new writer
-	writer, err := NewTableWriter(filename)
+	var err error
+	if p.appendMode {
+		writer, err = NewTableWriterAppend(p.fs, filename, p.compression, p.compressLevel)
+	} else {
+		writer, err = NewTableWriter(p.fs, filename, p.compression, p.compressLevel)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +221,32 @@ func (p *WriterPool) GetWriter(tableName, filename string) (*TableWriter, error)
 	return writer, nil
 }
 
+// Writer returns tableName's currently open writer, if any, without
+// creating one.
+func (p *WriterPool) Writer(tableName string) (*TableWriter, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	writer, exists := p.writers[tableName]
+	return writer, exists
+}
+
+// CloseWriter closes and removes tableName's writer from the pool, if one
+// exists, so a subsequent GetWriter call creates a fresh one. Used to
+// rotate a table to a new output file mid-split.
+func (p *WriterPool) CloseWriter(tableName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	writer, exists := p.writers[tableName]
+	if !exists {
+		return nil
+	}
+	delete(p.writers, tableName)
+
+	return writer.Close()
+}
+
 // CloseAll closes all writers in the pool
 func (p *WriterPool) CloseAll() error {
 	p.mu.Lock()
@@ -158,3 +261,21 @@ func (p *WriterPool) CloseAll() error {
 
 	return lastErr
 }
+
+// FlushAll flushes every writer in the pool to disk without closing it, so
+// their output is durable (e.g. before recording a resume checkpoint) while
+// still accepting further writes. For a writer with output compression only
+// the buffered layer is flushed; bytes still held inside the compressor
+// reach the file only on Close.
+func (p *WriterPool) FlushAll() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, writer := range p.writers {
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,16 +1,22 @@
 package writer
 
 import (
+	"compress/gzip"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/helgesverre/sql-splitter/internal/compress"
+	"github.com/spf13/afero"
 )
 
 func TestTableWriter_WriteStatement(t *testing.T) {
 	tmpDir := t.TempDir()
 	filename := filepath.Join(tmpDir, "test.sql")
 
-	w, err := NewTableWriter(filename)
+	fs := afero.NewOsFs()
+	w, err := NewTableWriter(fs, filename, compress.None, 0)
 	if err != nil {
 		t.Fatalf("failed to create writer: %v", err)
 	}
@@ -47,7 +53,7 @@ func TestTableWriter_WriteStatement(t *testing.T) {
 
 func TestWriterPool(t *testing.T) {
 	tmpDir := t.TempDir()
-	pool := NewWriterPool()
+	pool := NewWriterPool(afero.NewOsFs(), compress.None, 0)
 
 	// Get writer for table1
 	w1, err := pool.GetWriter("table1", filepath.Join(tmpDir, "table1.sql"))
@@ -85,7 +91,8 @@ func BenchmarkTableWriter_WriteStatement(b *testing.B) {
 	tmpDir := b.TempDir()
 	filename := filepath.Join(tmpDir, "bench.sql")
 
-	w, err := NewTableWriter(filename)
+	fs := afero.NewOsFs()
+	w, err := NewTableWriter(fs, filename, compress.None, 0)
 	if err != nil {
 		b.Fatalf("failed to create writer: %v", err)
 	}
@@ -116,7 +123,8 @@ func BenchmarkTableWriter_Buffering(b *testing.B) {
 			b.ResetTimer()
 
 			for i := 0; i < b.N; i++ {
-				w, err := NewTableWriter(filename)
+				fs := afero.NewOsFs()
+				w, err := NewTableWriter(fs, filename, compress.None, 0)
 				if err != nil {
 					b.Fatal(err)
 				}
@@ -136,3 +144,43 @@ func BenchmarkTableWriter_Buffering(b *testing.B) {
 		})
 	}
 }
+
+func TestTableWriter_GzipCompression(t *testing.T) {
+	tmpDir := t.TempDir()
+	filename := filepath.Join(tmpDir, "test.sql.gz")
+
+	w, err := NewTableWriter(afero.NewOsFs(), filename, compress.Gzip, 0)
+	if err != nil {
+		t.Fatalf("failed to create writer: %v", err)
+	}
+
+	if err := w.WriteStatement([]byte("INSERT INTO users VALUES (1);")); err != nil {
+		t.Fatalf("failed to write statement: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close writer: %v", err)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("expected a valid gzip stream: %v", err)
+	}
+	defer gr.Close()
+
+	content, err := io.ReadAll(gr)
+	if err != nil {
+		t.Fatalf("failed to read gzip content: %v", err)
+	}
+
+	expected := "INSERT INTO users VALUES (1);\n"
+	if string(content) != expected {
+		t.Errorf("content mismatch:\nwant: %q\ngot:  %q", expected, string(content))
+	}
+}